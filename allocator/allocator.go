@@ -0,0 +1,34 @@
+// Package allocator picks free subnets out of a parent network.
+package allocator
+
+import (
+	"github.com/frankgreco/terraform-helpers/internal/overlap"
+	"github.com/frankgreco/terraform-helpers/internal/utils"
+)
+
+// NextCIDR returns the first subnet of parent with the provided prefix length
+// that does not overlap with any of the used CIDRs. An error is returned when
+// the parent network has no such subnet left.
+func NextCIDR(parent string, used []string, prefixLength int) (string, error) {
+	return overlap.FirstFreeCIDR(parent, used, prefixLength)
+}
+
+// isFree reports whether the CIDR is within the parent network and does not
+// overlap with any of the used CIDRs, i.e. whether it could still be allocated.
+func isFree(cidr, parent string, used []string) bool {
+	pair, err := overlap.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	parentPair, err := overlap.ParseCIDR(parent)
+	if err != nil || !utils.Contains(parentPair, pair) {
+		return false
+	}
+	for _, u := range used {
+		usedPair, err := overlap.ParseCIDR(u)
+		if err != nil || utils.Intersects(usedPair, pair) {
+			return false
+		}
+	}
+	return true
+}
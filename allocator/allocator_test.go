@@ -0,0 +1,130 @@
+package allocator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextCIDR(t *testing.T) {
+	cidr, err := NextCIDR("10.0.0.0/16", []string{"10.0.0.0/24", "10.0.1.0/24"}, 24)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.2.0/24", cidr)
+
+	_, err = NextCIDR("10.0.0.0/24", []string{"10.0.0.0/24"}, 24)
+	require.EqualError(t, err, "There are no free /24 subnets left in 10.0.0.0/24.")
+}
+
+func TestNextCIDRModifier(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		parent   tftypes.Value
+		used     tftypes.Value
+		state    attr.Value
+		expected attr.Value
+		err      bool
+	}{
+		{
+			name:     "allocates",
+			parent:   tftypes.NewValue(tftypes.String, "10.0.0.0/16"),
+			used:     usedValue("10.0.0.0/24", "10.0.1.0/24"),
+			expected: types.String{Value: "10.0.2.0/24"},
+		},
+		{
+			name:     "no used cidrs",
+			parent:   tftypes.NewValue(tftypes.String, "10.0.0.0/16"),
+			used:     tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+			expected: types.String{Value: "10.0.0.0/24"},
+		},
+		{
+			name:     "keeps the state",
+			parent:   tftypes.NewValue(tftypes.String, "10.0.0.0/16"),
+			used:     usedValue(),
+			state:    types.String{Value: "10.0.7.0/24"},
+			expected: types.String{Value: "10.0.7.0/24"},
+		},
+		{
+			name:     "changed parent",
+			parent:   tftypes.NewValue(tftypes.String, "10.1.0.0/16"),
+			used:     usedValue(),
+			state:    types.String{Value: "10.0.7.0/24"},
+			expected: types.String{Value: "10.1.0.0/24"},
+		},
+		{
+			name:     "state is used",
+			parent:   tftypes.NewValue(tftypes.String, "10.0.0.0/16"),
+			used:     usedValue("10.0.7.0/25"),
+			state:    types.String{Value: "10.0.7.0/24"},
+			expected: types.String{Value: "10.0.0.0/24"},
+		},
+		{
+			name:     "unknown parent",
+			parent:   tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			used:     usedValue(),
+			expected: types.String{Unknown: true},
+		},
+		{
+			name:     "unknown used cidr",
+			parent:   tftypes.NewValue(tftypes.String, "10.0.0.0/16"),
+			used:     tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, tftypes.UnknownValue)}),
+			expected: types.String{Unknown: true},
+		},
+		{
+			name:     "exhausted",
+			parent:   tftypes.NewValue(tftypes.String, "10.0.0.0/24"),
+			used:     usedValue("10.0.0.0/24"),
+			expected: types.String{Unknown: true},
+			err:      true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			schema := tfsdk.Schema{
+				Attributes: map[string]tfsdk.Attribute{
+					"vpc_cidr":     {Type: types.StringType, Required: true},
+					"subnet_cidrs": {Type: types.ListType{ElemType: types.StringType}, Optional: true},
+					"cidr":         {Type: types.StringType, Computed: true},
+				},
+			}
+
+			req := tfsdk.ModifyAttributePlanRequest{
+				AttributePath: tftypes.NewAttributePath().WithAttributeName("cidr"),
+				Plan: tfsdk.Plan{
+					Schema: schema,
+					Raw: tftypes.NewValue(schema.TerraformType(ctx), map[string]tftypes.Value{
+						"vpc_cidr":     test.parent,
+						"subnet_cidrs": test.used,
+						"cidr":         tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+					}),
+				},
+				AttributeConfig: types.String{Null: true},
+				AttributeState:  test.state,
+				AttributePlan:   types.String{Unknown: true},
+			}
+			resp := tfsdk.ModifyAttributePlanResponse{
+				AttributePlan: req.AttributePlan,
+				Diagnostics:   diag.Diagnostics{},
+			}
+
+			NextCIDRModifier("vpc_cidr", "subnet_cidrs", 24).Modify(ctx, req, &resp)
+
+			require.Equal(t, test.err, resp.Diagnostics.HasError(), test.name)
+			require.Equal(t, test.expected, resp.AttributePlan, test.name)
+		})
+	}
+}
+
+func usedValue(cidrs ...string) tftypes.Value {
+	elems := []tftypes.Value{}
+	for _, cidr := range cidrs {
+		elems = append(elems, tftypes.NewValue(tftypes.String, cidr))
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elems)
+}
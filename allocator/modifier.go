@@ -0,0 +1,143 @@
+package allocator
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	nextCIDRErr         = "Unable to allocate a CIDR."
+	nextCIDRDescription = "Allocates the first free subnet of the parent network that does not overlap with the CIDRs already in use."
+)
+
+type nextCIDRModifier struct {
+	parent, used string
+	prefixLength int
+}
+
+// NextCIDRModifier returns a plan modifier for a computed string attribute that
+// fills it with the first free subnet, of the provided prefix length, of the network
+// held by the parent attribute that does not overlap with any CIDR held by the used
+// attribute. Both attributes must be at the same level as the modified attribute;
+// parent must be a string and used a list or set of strings. Once allocated,
+// the CIDR is kept for as long as it is in the state, within the parent network
+// and free of the used CIDRs, and is allocated again otherwise.
+func NextCIDRModifier(parent, used string, prefixLength int) tfsdk.AttributePlanModifier {
+	return nextCIDRModifier{
+		parent:       parent,
+		used:         used,
+		prefixLength: prefixLength,
+	}
+}
+
+// Description describes this plan modifier.
+func (m nextCIDRModifier) Description(context.Context) string {
+	return nextCIDRDescription
+}
+
+// MarkdownDescription describes this plan modifier.
+func (m nextCIDRModifier) MarkdownDescription(context.Context) string {
+	return nextCIDRDescription
+}
+
+// Modify modifies the plan of an attribute.
+func (m nextCIDRModifier) Modify(ctx context.Context, req tfsdk.ModifyAttributePlanRequest, resp *tfsdk.ModifyAttributePlanResponse) {
+	var plan types.String
+	{
+		resp.Diagnostics.Append(tfsdk.ValueAs(ctx, resp.AttributePlan, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Only an attribute that is yet to be computed needs a CIDR.
+	if !plan.Unknown {
+		return
+	}
+
+	var state types.String
+	if req.AttributeState != nil {
+		resp.Diagnostics.Append(tfsdk.ValueAs(ctx, req.AttributeState, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var parent types.Object
+	{
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, req.AttributePath.WithoutLastStep(), &parent)...)
+		if resp.Diagnostics.HasError() || parent.Null || parent.Unknown {
+			return
+		}
+	}
+
+	var network types.String
+	{
+		value, ok := parent.Attrs[m.parent]
+		if !ok {
+			return
+		}
+		resp.Diagnostics.Append(tfsdk.ValueAs(ctx, value, &network)...)
+		if resp.Diagnostics.HasError() || network.Null || network.Unknown {
+			return
+		}
+	}
+
+	var used []string
+	if value, ok := parent.Attrs[m.used]; ok {
+		var elems []types.String
+		{
+			switch collection := value.(type) {
+			case types.List:
+				if collection.Unknown {
+					return
+				}
+				resp.Diagnostics.Append(collection.ElementsAs(ctx, &elems, false)...)
+			case types.Set:
+				if collection.Unknown {
+					return
+				}
+				resp.Diagnostics.Append(collection.ElementsAs(ctx, &elems, false)...)
+			default:
+				resp.Diagnostics.AddAttributeError(
+					req.AttributePath,
+					nextCIDRErr,
+					"The attribute "+m.used+" must be a list or set of strings.",
+				)
+			}
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		for _, elem := range elems {
+			// The allocation can't be made until every used CIDR is known.
+			if elem.Unknown {
+				return
+			}
+			if !elem.Null {
+				used = append(used, elem.Value)
+			}
+		}
+	}
+
+	// The CIDR in the state is kept for as long as it could still be allocated.
+	if !state.Unknown && !state.Null && isFree(state.Value, network.Value, used) {
+		resp.AttributePlan = types.String{Value: state.Value}
+		return
+	}
+
+	cidr, err := NextCIDR(network.Value, used, m.prefixLength)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			nextCIDRErr,
+			err.Error(),
+		)
+		return
+	}
+
+	resp.AttributePlan = types.String{Value: cidr}
+}
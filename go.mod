@@ -24,7 +24,6 @@ require (
 	github.com/vmihailenco/msgpack v4.0.4+incompatible // indirect
 	golang.org/x/net v0.0.0-20210119194325-5f4716e94777 // indirect
 	golang.org/x/sys v0.0.0-20201119102817-f84b799fce68 // indirect
-	golang.org/x/text v0.3.3 // indirect
 	google.golang.org/appengine v1.6.5 // indirect
 	google.golang.org/genproto v0.0.0-20190819201941-24fa4b261c55 // indirect
 	google.golang.org/grpc v1.32.0 // indirect
@@ -34,4 +33,5 @@ require (
 require (
 	github.com/hashicorp/terraform-plugin-go v0.5.0
 	github.com/stretchr/testify v1.3.0
+	golang.org/x/text v0.3.3
 )
@@ -0,0 +1,453 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Value is the value of an expression or identifier. It is one of:
+//
+//   - *big.Rat for numbers, which keeps arithmetic exact.
+//   - string
+//   - bool
+//   - []Value for lists, sets and tuples.
+//   - map[string]Value for maps and objects.
+//   - Unknown for values that are not known yet.
+type Value interface{}
+
+type unknown struct{}
+
+// Unknown is a value that is not known yet. Any expression that
+// depends on an unknown value is unknown as well.
+var Unknown Value = unknown{}
+
+// Expression is a parsed expression.
+type Expression struct {
+	src         string
+	root        node
+	identifiers []string
+}
+
+// Parse parses the expression. An expression is made of:
+//
+//   - number, string ("...") and bool (true, false) literals.
+//   - identifiers, whose values are supplied when the expression is evaluated.
+//   - attribute access, e.g. autoscaling.max.
+//   - arithmetic: +, -, *, / and % (integers only). + also concatenates strings.
+//   - comparisons: ==, !=, <, <=, > and >=.
+//   - logical operators: &&, || and !.
+//   - functions: len(x) of a string, list or map.
+//   - parentheses.
+func Parse(src string) (*Expression, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid expression %q: %s", src, err)
+	}
+
+	p := &parser{
+		tokens:      tokens,
+		identifiers: map[string]bool{},
+	}
+	root, err := p.parseBinary(1)
+	if err == nil && p.peek().kind != tokenEOF {
+		err = unexpected(p.peek(), "an operator")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid expression %q: %s", src, err)
+	}
+
+	identifiers := make([]string, 0, len(p.identifiers))
+	for name := range p.identifiers {
+		identifiers = append(identifiers, name)
+	}
+	sort.Strings(identifiers)
+
+	return &Expression{
+		src:         src,
+		root:        root,
+		identifiers: identifiers,
+	}, nil
+}
+
+// Identifiers returns the names of the identifiers used by the expression.
+func (e *Expression) Identifiers() []string {
+	return e.identifiers
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.src
+}
+
+// Eval evaluates the expression, where env holds the value of every identifier.
+func (e *Expression) Eval(env map[string]Value) (Value, error) {
+	ev := &evaluator{
+		src:    e.src,
+		env:    env,
+		values: map[node]Value{},
+	}
+	return ev.eval(e.root)
+}
+
+// Check evaluates an expression that must result in a bool. If it is false,
+// reason describes the sub-expression that failed, along with the values of
+// its operands, e.g. `self >= min_size * 2 (5 >= 6)`. known is false if the
+// result depends on an unknown value.
+func (e *Expression) Check(env map[string]Value) (ok, known bool, reason string, err error) {
+	ev := &evaluator{
+		src:    e.src,
+		env:    env,
+		values: map[node]Value{},
+	}
+
+	result, err := ev.eval(e.root)
+	if err != nil {
+		return false, false, "", err
+	}
+	if result == Unknown {
+		return false, false, "", nil
+	}
+
+	b, isBool := result.(bool)
+	if !isBool {
+		return false, false, "", fmt.Errorf("The expression %q must result in a bool, not a %s.", e.src, typeName(result))
+	}
+	if b {
+		return true, true, "", nil
+	}
+	return false, true, ev.explain(e.root), nil
+}
+
+type evaluator struct {
+	src    string
+	env    map[string]Value
+	values map[node]Value
+}
+
+func (ev *evaluator) text(n node) string {
+	start, end := n.span()
+	return ev.src[start:end]
+}
+
+func (ev *evaluator) eval(n node) (Value, error) {
+	value, err := ev.evalNode(n)
+	if err != nil {
+		return nil, err
+	}
+	ev.values[n] = value
+	return value, nil
+}
+
+func (ev *evaluator) evalNode(n node) (Value, error) {
+	switch n := n.(type) {
+	case *literalNode:
+		return n.value, nil
+	case *parenNode:
+		return ev.eval(n.inner)
+	case *identNode:
+		value, ok := ev.env[n.name]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown identifier %q", ev.text(n), n.name)
+		}
+		return value, nil
+	case *memberNode:
+		object, err := ev.eval(n.object)
+		if err != nil || object == Unknown {
+			return object, err
+		}
+		attrs, ok := object.(map[string]Value)
+		if !ok {
+			return nil, fmt.Errorf("%s: a %s does not have attributes", ev.text(n), typeName(object))
+		}
+		value, ok := attrs[n.name]
+		if !ok {
+			return nil, fmt.Errorf("%s: there is no attribute %q", ev.text(n), n.name)
+		}
+		return value, nil
+	case *callNode:
+		return ev.evalCall(n)
+	case *unaryNode:
+		operand, err := ev.eval(n.operand)
+		if err != nil || operand == Unknown {
+			return operand, err
+		}
+		switch v := operand.(type) {
+		case bool:
+			if n.op == "!" {
+				return !v, nil
+			}
+		case *big.Rat:
+			if n.op == "-" {
+				return new(big.Rat).Neg(v), nil
+			}
+		}
+		return nil, fmt.Errorf("%s: cannot apply %s to a %s", ev.text(n), n.op, typeName(operand))
+	case *binaryNode:
+		return ev.evalBinary(n)
+	}
+	return nil, errors.New("unknown node")
+}
+
+func (ev *evaluator) evalCall(n *callNode) (Value, error) {
+	arg, err := ev.eval(n.args[0])
+	if err != nil || arg == Unknown {
+		return arg, err
+	}
+
+	// len is the only function.
+	switch v := arg.(type) {
+	case string:
+		return new(big.Rat).SetInt64(int64(utf8.RuneCountInString(v))), nil
+	case []Value:
+		return new(big.Rat).SetInt64(int64(len(v))), nil
+	case map[string]Value:
+		return new(big.Rat).SetInt64(int64(len(v))), nil
+	}
+	return nil, fmt.Errorf("%s: cannot take the length of a %s", ev.text(n), typeName(arg))
+}
+
+func (ev *evaluator) evalBinary(n *binaryNode) (Value, error) {
+	left, err := ev.eval(n.left)
+	if err != nil {
+		return nil, err
+	}
+
+	// && and || short-circuit, which also lets them be decided
+	// when the other operand is unknown.
+	if n.op == "&&" || n.op == "||" {
+		// The value that decides the result on its own.
+		decisive := n.op == "||"
+
+		if err := ev.checkBool(n, left); err != nil {
+			return nil, err
+		}
+		if left == decisive {
+			return left, nil
+		}
+
+		right, err := ev.eval(n.right)
+		if err != nil {
+			return nil, err
+		}
+		if err := ev.checkBool(n, right); err != nil {
+			return nil, err
+		}
+		if right == decisive {
+			return right, nil
+		}
+		if left == Unknown {
+			return Unknown, nil
+		}
+		return right, nil
+	}
+
+	right, err := ev.eval(n.right)
+	if err != nil {
+		return nil, err
+	}
+	if left == Unknown || right == Unknown {
+		return Unknown, nil
+	}
+
+	switch n.op {
+	case "==", "!=":
+		if typeName(left) != typeName(right) {
+			return nil, fmt.Errorf("%s: cannot compare a %s to a %s", ev.text(n), typeName(left), typeName(right))
+		}
+		// Lists and objects can't be compared while any of their elements is unknown.
+		if containsUnknown(left) || containsUnknown(right) {
+			return Unknown, nil
+		}
+		return (key(left) == key(right)) == (n.op == "=="), nil
+	case "<", "<=", ">", ">=":
+		c, err := ev.compare(n, left, right)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		}
+		return c >= 0, nil
+	}
+
+	// Arithmetic.
+	if l, ok := left.(string); ok && n.op == "+" {
+		if r, ok := right.(string); ok {
+			return l + r, nil
+		}
+	}
+	l, lok := left.(*big.Rat)
+	r, rok := right.(*big.Rat)
+	if !lok || !rok {
+		return nil, fmt.Errorf("%s: cannot apply %s to a %s and a %s", ev.text(n), n.op, typeName(left), typeName(right))
+	}
+
+	switch n.op {
+	case "+":
+		return new(big.Rat).Add(l, r), nil
+	case "-":
+		return new(big.Rat).Sub(l, r), nil
+	case "*":
+		return new(big.Rat).Mul(l, r), nil
+	case "/":
+		if r.Sign() == 0 {
+			return nil, fmt.Errorf("%s: division by zero", ev.text(n))
+		}
+		return new(big.Rat).Quo(l, r), nil
+	case "%":
+		if !l.IsInt() || !r.IsInt() {
+			return nil, fmt.Errorf("%s: cannot apply %% to numbers that are not whole", ev.text(n))
+		}
+		if r.Sign() == 0 {
+			return nil, fmt.Errorf("%s: division by zero", ev.text(n))
+		}
+		return new(big.Rat).SetInt(new(big.Int).Rem(l.Num(), r.Num())), nil
+	}
+	return nil, fmt.Errorf("%s: unknown operator %s", ev.text(n), n.op)
+}
+
+// checkBool ensures that the operand of the logical operator n is a bool.
+func (ev *evaluator) checkBool(n *binaryNode, operand Value) error {
+	if _, ok := operand.(bool); !ok && operand != Unknown {
+		return fmt.Errorf("%s: cannot apply %s to a %s", ev.text(n), n.op, typeName(operand))
+	}
+	return nil
+}
+
+// compare orders numbers numerically and strings lexicographically.
+func (ev *evaluator) compare(n *binaryNode, left, right Value) (int, error) {
+	switch l := left.(type) {
+	case *big.Rat:
+		if r, ok := right.(*big.Rat); ok {
+			return l.Cmp(r), nil
+		}
+	case string:
+		if r, ok := right.(string); ok {
+			return strings.Compare(l, r), nil
+		}
+	}
+	return 0, fmt.Errorf("%s: cannot compare a %s to a %s with %s", ev.text(n), typeName(left), typeName(right), n.op)
+}
+
+// explain describes the sub-expression of n that made it false.
+func (ev *evaluator) explain(n node) string {
+	switch n := n.(type) {
+	case *parenNode:
+		return ev.explain(n.inner)
+	case *binaryNode:
+		switch n.op {
+		case "&&":
+			if ev.values[n.left] == false {
+				return ev.explain(n.left)
+			}
+			return ev.explain(n.right)
+		case "==", "!=", "<", "<=", ">", ">=":
+			return fmt.Sprintf("%s (%s %s %s)", ev.text(n), Format(ev.values[n.left]), n.op, Format(ev.values[n.right]))
+		}
+	}
+	return ev.text(n)
+}
+
+// Format returns the value in the form it would be written in an expression.
+func Format(value Value) string {
+	switch v := value.(type) {
+	case *big.Rat:
+		if v.IsInt() {
+			return v.Num().String()
+		}
+		return new(big.Float).SetPrec(256).SetRat(v).Text('g', -1)
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case []Value:
+		formatted := make([]string, len(v))
+		for i, elem := range v {
+			formatted[i] = Format(elem)
+		}
+		return "[" + strings.Join(formatted, ", ") + "]"
+	case map[string]Value:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		formatted := make([]string, len(keys))
+		for i, k := range keys {
+			formatted[i] = k + " = " + Format(v[k])
+		}
+		return "{" + strings.Join(formatted, ", ") + "}"
+	}
+	return "(known after apply)"
+}
+
+// key returns a string that is the same for two values if, and only if, they are equal.
+func key(value Value) string {
+	switch v := value.(type) {
+	case *big.Rat:
+		return v.RatString()
+	case []Value:
+		keys := make([]string, len(v))
+		for i, elem := range v {
+			keys[i] = key(elem)
+		}
+		return "[" + strings.Join(keys, ", ") + "]"
+	case map[string]Value:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		formatted := make([]string, len(keys))
+		for i, k := range keys {
+			formatted[i] = strconv.Quote(k) + " = " + key(v[k])
+		}
+		return "{" + strings.Join(formatted, ", ") + "}"
+	}
+	return Format(value)
+}
+
+// containsUnknown reports whether the value is unknown, or has an unknown element.
+func containsUnknown(value Value) bool {
+	switch v := value.(type) {
+	case []Value:
+		for _, elem := range v {
+			if containsUnknown(elem) {
+				return true
+			}
+		}
+	case map[string]Value:
+		for _, elem := range v {
+			if containsUnknown(elem) {
+				return true
+			}
+		}
+	}
+	return value == Unknown
+}
+
+func typeName(value Value) string {
+	switch value.(type) {
+	case *big.Rat:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case []Value:
+		return "list"
+	case map[string]Value:
+		return "object"
+	}
+	return "unknown value"
+}
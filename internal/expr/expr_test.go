@@ -0,0 +1,212 @@
+package expr
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func number(s string) Value {
+	r, _ := new(big.Rat).SetString(s)
+	return r
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		src         string
+		identifiers []string
+		err         string
+	}{
+		{
+			name:        "comparison",
+			src:         "self <= max_size && self >= min_size * 2",
+			identifiers: []string{"max_size", "min_size", "self"},
+		},
+		{
+			name:        "function",
+			src:         "len(zones) >= replicas",
+			identifiers: []string{"replicas", "zones"},
+		},
+		{
+			name:        "member",
+			src:         `autoscaling.max <= 10 || !(name == "a\"b")`,
+			identifiers: []string{"autoscaling", "name"},
+		},
+		{
+			name: "unexpected character",
+			src:  "self # 1",
+			err:  `Invalid expression "self # 1": unexpected character '#' at position 5`,
+		},
+		{
+			name: "missing operand",
+			src:  "self <=",
+			err:  `Invalid expression "self <=": expected a value at the end of the expression`,
+		},
+		{
+			name: "missing operator",
+			src:  "self 1",
+			err:  `Invalid expression "self 1": expected an operator but found "1" at position 5`,
+		},
+		{
+			name: "unbalanced parentheses",
+			src:  "(self < 1",
+			err:  `Invalid expression "(self < 1": expected ) at the end of the expression`,
+		},
+		{
+			name: "unknown function",
+			src:  "size(zones) > 1",
+			err:  `Invalid expression "size(zones) > 1": unknown function "size" at position 0`,
+		},
+		{
+			name: "wrong number of arguments",
+			src:  "len(a, b) > 1",
+			err:  `Invalid expression "len(a, b) > 1": len() at position 0 takes 1 argument(s) but was given 2`,
+		},
+		{
+			name: "unterminated string",
+			src:  `name == "abc`,
+			err:  `Invalid expression "name == \"abc": invalid string at position 8`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			e, err := Parse(test.src)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.identifiers, e.Identifiers())
+		})
+	}
+}
+
+func TestEval(t *testing.T) {
+	env := map[string]Value{
+		"self":     number("5"),
+		"min_size": number("3"),
+		"max_size": number("10"),
+		"ratio":    number("0.1"),
+		"name":     "web",
+		"enabled":  true,
+		"zones":    []Value{"a", "b"},
+		"limits":   map[string]Value{"max": number("4")},
+		"pending":  Unknown,
+		"partial":  []Value{Unknown},
+		"object":   map[string]Value{"max": Unknown},
+	}
+
+	for _, test := range []struct {
+		name     string
+		src      string
+		expected Value
+		err      string
+	}{
+		{name: "arithmetic", src: "1 + 2 * 3 - 4 / 2", expected: number("5")},
+		{name: "parentheses", src: "(1 + 2) * 3", expected: number("9")},
+		{name: "exact", src: "ratio * 3 == 0.3", expected: true},
+		{name: "modulo", src: "self % 3", expected: number("2")},
+		{name: "negation", src: "-self", expected: number("-5")},
+		{name: "concatenation", src: `name + "-1"`, expected: "web-1"},
+		{name: "string comparison", src: `name < "xyz"`, expected: true},
+		{name: "len", src: "len(zones) + len(name) + len(limits)", expected: number("6")},
+		{name: "member", src: "limits.max < self", expected: true},
+		{name: "logical", src: "enabled && !(self > max_size) || false", expected: true},
+		{name: "unknown", src: "pending + 1", expected: Unknown},
+		{name: "unknown and false", src: "pending > 1 && self > 10", expected: false},
+		{name: "unknown or true", src: "pending > 1 || self < 10", expected: true},
+		{name: "unknown and true", src: "pending > 1 && self < 10", expected: Unknown},
+		{name: "list equality", src: "zones == zones", expected: true},
+		{name: "nested unknown equality", src: "partial == partial", expected: Unknown},
+		{name: "nested unknown inequality", src: "partial != zones", expected: Unknown},
+		{name: "nested unknown object", src: "object == limits", expected: Unknown},
+		{name: "type mismatch", src: "self < name", err: `self < name: cannot compare a number to a string with <`},
+		{name: "equality type mismatch", src: `self == "5"`, err: `self == "5": cannot compare a number to a string`},
+		{name: "not a bool", src: "self && enabled", err: "self && enabled: cannot apply && to a number"},
+		{name: "division by zero", src: "self / (min_size - 3)", err: "self / (min_size - 3): division by zero"},
+		{name: "fractional modulo", src: "ratio % 2", err: "ratio % 2: cannot apply % to numbers that are not whole"},
+		{name: "missing attribute", src: "limits.min", err: `limits.min: there is no attribute "min"`},
+		{name: "unknown identifier", src: "other", err: `other: unknown identifier "other"`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			e, err := Parse(test.src)
+			require.NoError(t, err)
+
+			value, err := e.Eval(env)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, key(test.expected), key(value))
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	env := map[string]Value{
+		"self":     number("5"),
+		"min_size": number("3"),
+		"max_size": number("10"),
+		"pending":  Unknown,
+	}
+
+	for _, test := range []struct {
+		name   string
+		src    string
+		ok     bool
+		known  bool
+		reason string
+		err    string
+	}{
+		{
+			name:  "pass",
+			src:   "self <= max_size && self >= min_size",
+			ok:    true,
+			known: true,
+		},
+		{
+			name:   "failed sub-expression",
+			src:    "self <= max_size && self >= min_size * 2",
+			known:  true,
+			reason: "self >= min_size * 2 (5 >= 6)",
+		},
+		{
+			name:   "failed parenthesized sub-expression",
+			src:    "(self > max_size && self > 0) && self > min_size",
+			known:  true,
+			reason: "self > max_size (5 > 10)",
+		},
+		{
+			name:   "failed disjunction",
+			src:    "self > max_size || self < min_size",
+			known:  true,
+			reason: "self > max_size || self < min_size",
+		},
+		{
+			name: "unknown",
+			src:  "self < pending",
+		},
+		{
+			name: "not a bool",
+			src:  "self + 1",
+			err:  `The expression "self + 1" must result in a bool, not a number.`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			e, err := Parse(test.src)
+			require.NoError(t, err)
+
+			ok, known, reason, err := e.Check(env)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.ok, ok)
+			require.Equal(t, test.known, known)
+			require.Equal(t, test.reason, reason)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+var (
+	numberRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?([eE][+-]?[0-9]+)?`)
+	identRegex  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*`)
+
+	// operators are ordered so that the longest match is tried first.
+	operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "+", "-", "*", "/", "%", "!", "(", ")", ",", "."}
+)
+
+// lex splits the source into tokens, the last of which is always tokenEOF.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(src); {
+		rest := src[pos:]
+
+		switch r := rune(rest[0]); {
+		case unicode.IsSpace(r):
+			pos++
+			continue
+		case r >= '0' && r <= '9':
+			text := numberRegex.FindString(rest)
+			tokens = append(tokens, token{kind: tokenNumber, text: text, pos: pos})
+			pos += len(text)
+			continue
+		case r == '"':
+			text, err := quotedPrefix(rest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d", pos)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: pos})
+			pos += len(text)
+			continue
+		}
+
+		if text := identRegex.FindString(rest); text != "" {
+			tokens = append(tokens, token{kind: tokenIdent, text: text, pos: pos})
+			pos += len(text)
+			continue
+		}
+
+		matched := false
+		for _, op := range operators {
+			if strings.HasPrefix(rest, op) {
+				tokens = append(tokens, token{kind: tokenOperator, text: op, pos: pos})
+				pos += len(op)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("unexpected character %q at position %d", rest[0], pos)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+// quotedPrefix returns the double quoted string at the start of s.
+func quotedPrefix(s string) (string, error) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			if _, err := strconv.Unquote(s[:i+1]); err != nil {
+				return "", err
+			}
+			return s[:i+1], nil
+		}
+	}
+	return "", strconv.ErrSyntax
+}
@@ -0,0 +1,235 @@
+package expr
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// node is a node of the syntax tree. start and end are the offsets
+// of the source text from which the node was parsed.
+type node interface {
+	span() (start, end int)
+}
+
+type literalNode struct {
+	start, end int
+	value      Value
+}
+
+type identNode struct {
+	start, end int
+	name       string
+}
+
+type memberNode struct {
+	start, end int
+	object     node
+	name       string
+}
+
+type callNode struct {
+	start, end int
+	name       string
+	args       []node
+}
+
+type unaryNode struct {
+	start, end int
+	op         string
+	operand    node
+}
+
+type binaryNode struct {
+	start, end  int
+	op          string
+	left, right node
+}
+
+type parenNode struct {
+	start, end int
+	inner      node
+}
+
+func (n *literalNode) span() (int, int) { return n.start, n.end }
+func (n *identNode) span() (int, int)   { return n.start, n.end }
+func (n *memberNode) span() (int, int)  { return n.start, n.end }
+func (n *callNode) span() (int, int)    { return n.start, n.end }
+func (n *unaryNode) span() (int, int)   { return n.start, n.end }
+func (n *binaryNode) span() (int, int)  { return n.start, n.end }
+func (n *parenNode) span() (int, int)   { return n.start, n.end }
+
+// precedence of the binary operators, from lowest to highest.
+var precedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+// functions maps the name of every function to its number of arguments.
+var functions = map[string]int{
+	"len": 1,
+}
+
+type parser struct {
+	tokens      []token
+	pos         int
+	identifiers map[string]bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// end returns the offset just past the last consumed token.
+func (p *parser) end() int {
+	t := p.tokens[p.pos-1]
+	return t.pos + len(t.text)
+}
+
+func (p *parser) expect(op string) error {
+	if t := p.next(); t.kind != tokenOperator || t.text != op {
+		return unexpected(t, op)
+	}
+	return nil
+}
+
+func unexpected(t token, expected string) error {
+	if t.kind == tokenEOF {
+		return fmt.Errorf("expected %s at the end of the expression", expected)
+	}
+	return fmt.Errorf("expected %s but found %q at position %d", expected, t.text, t.pos)
+}
+
+// parseBinary parses a chain of binary operators whose precedence is at least min.
+func (p *parser) parseBinary(min int) (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		prec, ok := precedence[t.text]
+		if t.kind != tokenOperator || !ok || prec < min {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseBinary(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		start, _ := left.span()
+		left = &binaryNode{start: start, end: p.end(), op: t.text, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if t := p.peek(); t.kind == tokenOperator && (t.text == "!" || t.text == "-") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{start: t.pos, end: p.end(), op: t.text, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for t := p.peek(); t.kind == tokenOperator && t.text == "."; t = p.peek() {
+		p.next()
+		name := p.next()
+		if name.kind != tokenIdent {
+			return nil, unexpected(name, "an attribute name")
+		}
+		start, _ := n.span()
+		n = &memberNode{start: start, end: p.end(), object: n, name: name.text}
+	}
+	return n, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		r, ok := new(big.Rat).SetString(t.text)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.text, t.pos)
+		}
+		return &literalNode{start: t.pos, end: p.end(), value: r}, nil
+	case tokenString:
+		s, err := strconv.Unquote(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s at position %d", t.text, t.pos)
+		}
+		return &literalNode{start: t.pos, end: p.end(), value: s}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true", "false":
+			return &literalNode{start: t.pos, end: p.end(), value: t.text == "true"}, nil
+		}
+		if next := p.peek(); next.kind == tokenOperator && next.text == "(" {
+			return p.parseCall(t)
+		}
+		p.identifiers[t.text] = true
+		return &identNode{start: t.pos, end: p.end(), name: t.text}, nil
+	case tokenOperator:
+		if t.text == "(" {
+			n, err := p.parseBinary(1)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return &parenNode{start: t.pos, end: p.end(), inner: n}, nil
+		}
+	}
+	return nil, unexpected(t, "a value")
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	arity, ok := functions[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos)
+	}
+	p.next() // (
+
+	var args []node
+	for t := p.peek(); t.kind != tokenOperator || t.text != ")"; t = p.peek() {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseBinary(1)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	p.next() // )
+
+	if len(args) != arity {
+		return nil, fmt.Errorf("%s() at position %d takes %d argument(s) but was given %d", name.text, name.pos, arity, len(args))
+	}
+	return &callNode{start: name.pos, end: p.end(), name: name.text, args: args}, nil
+}
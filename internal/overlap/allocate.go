@@ -0,0 +1,72 @@
+package overlap
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// FirstFreeCIDR returns the first subnet of parent with the provided prefix
+// length that does not overlap with any of the used CIDRs.
+func FirstFreeCIDR(parent string, used []string, prefixLength int) (string, error) {
+	parentNet, err := parseCIDR(parent)
+	if err != nil {
+		return "", err
+	}
+
+	ones, bits := parentNet.Mask.Size()
+	if prefixLength < ones || prefixLength > bits {
+		return "", fmt.Errorf("Invalid prefix length: /%d does not fit within %s", prefixLength, parentNet)
+	}
+
+	type span struct {
+		first, last *big.Int
+	}
+
+	var taken []span
+	for _, cidr := range used {
+		ipNet, err := parseCIDR(cidr)
+		if err != nil {
+			return "", err
+		}
+		// CIDRs of the other address family can never overlap.
+		if len(ipNet.IP) != len(parentNet.IP) {
+			continue
+		}
+		taken = append(taken, span{
+			first: new(big.Int).SetBytes(ipNet.IP),
+			last:  new(big.Int).SetBytes(lastIP(ipNet)),
+		})
+	}
+
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-prefixLength))
+	last := new(big.Int).SetBytes(lastIP(parentNet))
+
+	for first := new(big.Int).SetBytes(parentNet.IP); first.Cmp(last) <= 0; {
+		end := new(big.Int).Add(first, size)
+		end.Sub(end, big.NewInt(1))
+
+		// Find the furthest reaching CIDR that overlaps this candidate.
+		var reach *big.Int
+		for _, t := range taken {
+			if t.first.Cmp(end) > 0 || t.last.Cmp(first) < 0 {
+				continue
+			}
+			if reach == nil || t.last.Cmp(reach) > 0 {
+				reach = t.last
+			}
+		}
+
+		if reach == nil {
+			ip := make(net.IP, len(parentNet.IP))
+			first.FillBytes(ip)
+			return (&net.IPNet{IP: ip, Mask: net.CIDRMask(prefixLength, bits)}).String(), nil
+		}
+
+		// Move on to the first subnet boundary after it.
+		first = new(big.Int).Add(reach, size)
+		first.Sub(first, new(big.Int).Mod(first, size))
+	}
+
+	return "", fmt.Errorf("There are no free /%d subnets left in %s.", prefixLength, parentNet)
+}
@@ -0,0 +1,105 @@
+package overlap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstFreeCIDR(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		parent       string
+		used         []string
+		prefixLength int
+		cidr         string
+		err          string
+	}{
+		{
+			name:         "empty parent",
+			parent:       "10.0.0.0/16",
+			prefixLength: 24,
+			cidr:         "10.0.0.0/24",
+		},
+		{
+			name:         "skips used subnets",
+			parent:       "10.0.0.0/16",
+			used:         []string{"10.0.1.0/24", "10.0.0.0/24", "10.0.2.0/23"},
+			prefixLength: 24,
+			cidr:         "10.0.4.0/24",
+		},
+		{
+			name:         "fills holes",
+			parent:       "10.0.0.0/16",
+			used:         []string{"10.0.0.0/24", "10.0.2.0/24"},
+			prefixLength: 24,
+			cidr:         "10.0.1.0/24",
+		},
+		{
+			name:         "aligns after unaligned used cidr",
+			parent:       "10.0.0.0/16",
+			used:         []string{"10.0.0.0/25", "10.0.0.128"},
+			prefixLength: 23,
+			cidr:         "10.0.2.0/23",
+		},
+		{
+			name:         "ignores cidrs outside the parent",
+			parent:       "10.0.0.0/16",
+			used:         []string{"10.1.0.0/24", "2001:db8::/32"},
+			prefixLength: 24,
+			cidr:         "10.0.0.0/24",
+		},
+		{
+			name:         "used cidr covers the parent",
+			parent:       "10.0.0.0/16",
+			used:         []string{"10.0.0.0/8"},
+			prefixLength: 24,
+			err:          "There are no free /24 subnets left in 10.0.0.0/16.",
+		},
+		{
+			name:         "exhausted",
+			parent:       "10.0.0.0/23",
+			used:         []string{"10.0.0.0/24", "10.0.1.0/24"},
+			prefixLength: 24,
+			err:          "There are no free /24 subnets left in 10.0.0.0/23.",
+		},
+		{
+			name:         "exhausted at the end of the address space",
+			parent:       "255.255.255.0/24",
+			used:         []string{"255.255.255.0/24"},
+			prefixLength: 28,
+			err:          "There are no free /28 subnets left in 255.255.255.0/24.",
+		},
+		{
+			name:         "ipv6",
+			parent:       "2001:db8::/56",
+			used:         []string{"2001:db8::/64", "2001:db8:0:1::/64"},
+			prefixLength: 64,
+			cidr:         "2001:db8:0:2::/64",
+		},
+		{
+			name:         "prefix length too short",
+			parent:       "10.0.0.0/16",
+			prefixLength: 8,
+			err:          "Invalid prefix length: /8 does not fit within 10.0.0.0/16",
+		},
+		{
+			name:         "invalid used cidr",
+			parent:       "10.0.0.0/16",
+			used:         []string{"10.0.0.0/33"},
+			prefixLength: 24,
+			err:          "invalid CIDR address: 10.0.0.0/33",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cidr, err := FirstFreeCIDR(test.parent, test.used, test.prefixLength)
+			if test.err == "" {
+				require.NoError(t, err, test.name)
+			} else {
+				require.NotNil(t, err, test.name)
+				require.Equal(t, test.err, err.Error(), test.name)
+			}
+			require.Equal(t, test.cidr, cidr, test.name)
+		})
+	}
+}
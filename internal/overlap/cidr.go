@@ -1,7 +1,6 @@
 package overlap
 
 import (
-	"fmt"
 	"net"
 	"strings"
 
@@ -9,37 +8,107 @@ import (
 )
 
 func newCidrOrderedPair(cidr *net.IPNet) utils.OrderedPair {
-	last := make([]byte, 4)
-	for i := 0; i < 4; i++ {
-		last[i] = cidr.IP[i] | (cidr.Mask[i] ^ 255)
+	return utils.NewOrderedPair(utils.NewIPKey(firstIP(cidr)), utils.NewIPKey(lastIP(cidr)))
+}
+
+// firstIP returns the first address in the CIDR, i.e. its network address.
+func firstIP(cidr *net.IPNet) net.IP {
+	return cidr.IP.Mask(cidr.Mask)
+}
+
+// lastIP returns the last address in the CIDR.
+func lastIP(cidr *net.IPNet) net.IP {
+	first := firstIP(cidr)
+	last := make(net.IP, len(first))
+	for i := range first {
+		last[i] = first[i] | (cidr.Mask[i] ^ 255)
+	}
+	return last
+}
+
+// parseCIDR parses a CIDR, treating an address without a mask as a
+// single host (/32 for IPv4 and /128 for IPv6). IPv4 networks are
+// always returned in their 4 byte form.
+func parseCIDR(cidr string) (*net.IPNet, error) {
+	// If the cidr contains a '/' anywhere else, It's already malformed.
+	// Making it more malformed won't be an issue.
+	if !strings.Contains(cidr, "/") {
+		if strings.Contains(cidr, ":") {
+			cidr = cidr + "/128"
+		} else {
+			cidr = cidr + "/32"
+		}
+	}
+
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	if ip := ipNet.IP.To4(); ip != nil && len(ipNet.Mask) == net.IPv4len {
+		ipNet.IP = ip
+	}
+	return ipNet, nil
+}
+
+// ParseCIDR returns the range of addresses supplied by the CIDR. An
+// address without a mask supplies only itself.
+func ParseCIDR(cidr string) (utils.OrderedPair, error) {
+	ipNet, err := parseCIDR(cidr)
+	if err != nil {
+		return nil, err
 	}
-	return utils.NewOrderedPair(cidr.IP.Mask(cidr.Mask).To4().String(), net.IP(last).String())
+	return newCidrOrderedPair(ipNet), nil
 }
 
-func CIDR(encoded []string) error {
+// CanonicalCIDR returns the CIDR in its canonical form, i.e. the network
+// address followed by the mask, e.g. "10.0.0.1/24" becomes "10.0.0.0/24".
+// An address without a mask is a single host.
+func CanonicalCIDR(cidr string) (string, error) {
+	ipNet, err := parseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	return (&net.IPNet{IP: firstIP(ipNet), Mask: ipNet.Mask}).String(), nil
+}
+
+// CIDRWithin reports whether the encoded CIDR is fully contained in
+// at least one of the parent CIDRs.
+func CIDRWithin(encoded string, parents []string) (bool, error) {
+	cidr, err := ParseCIDR(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	for _, encodedParent := range parents {
+		parent, err := ParseCIDR(encodedParent)
+		if err != nil {
+			return false, err
+		}
+		if utils.Contains(parent, cidr) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CIDR returns every pair of encoded CIDRs that overlap. IPv4 and IPv6
+// CIDRs may be mixed, IPv4 addresses are always ordered before IPv6
+// addresses so the two families never overlap each other.
+func CIDR(encoded []string) ([]utils.Overlap, error) {
 	if len(encoded) < 2 {
-		return nil
+		return nil, nil
 	}
 
 	var cidrs []utils.OrderedPair
 	{
 		for _, cidr := range encoded {
-			// If the cidr contains a '/' anywhere else, It's already malformed.
-			// Making it more malformed won't be an issue.
-			if !strings.Contains(cidr, "/") {
-				cidr = cidr + "/32"
-			}
-
-			_, ipNet, err := net.ParseCIDR(cidr)
+			ipNet, err := parseCIDR(cidr)
 			if err != nil {
-				return err
-			}
-			if ipNet.IP.To4() == nil {
-				return fmt.Errorf("Invalid CIDR: %s is not IPv4", ipNet.IP.String())
+				return nil, err
 			}
 			cidrs = append(cidrs, newCidrOrderedPair(ipNet))
 		}
 	}
 
-	return utils.Overlaps(cidrs)
+	return utils.Overlaps(cidrs), nil
 }
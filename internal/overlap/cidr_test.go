@@ -9,9 +9,10 @@ import (
 
 func TestCIDR(t *testing.T) {
 	for _, test := range []struct {
-		name  string
-		cidrs []string
-		err   string
+		name     string
+		cidrs    []string
+		overlaps []string
+		err      string
 	}{
 		{
 			name: "no overlapping happy path",
@@ -37,7 +38,9 @@ func TestCIDR(t *testing.T) {
 				"192.168.3.0",
 				"192.168.3.0/32",
 			},
-			err: "The element 192.168.3.0 is supplied by more than one range.",
+			overlaps: []string{
+				"2,3: The element 192.168.3.0 is supplied by more than one range.",
+			},
 		},
 		{
 			name: "overlapping happy path",
@@ -45,16 +48,109 @@ func TestCIDR(t *testing.T) {
 				"192.168.1.0/24",
 				"192.168.2.0/16",
 			},
-			err: "The elements between 192.168.1.0 and 192.168.255.255 are supplied by more than one range.",
+			overlaps: []string{
+				"0,1: The elements between 192.168.1.0 and 192.168.1.255 are supplied by more than one range.",
+			},
+		},
+		{
+			name: "every overlap is reported",
+			cidrs: []string{
+				"10.0.0.0/8",
+				"192.168.0.0/24",
+				"10.1.0.0/16",
+				"192.168.0.0/24",
+				"10.1.2.0/24",
+			},
+			overlaps: []string{
+				"0,2: The elements between 10.1.0.0 and 10.1.255.255 are supplied by more than one range.",
+				"0,4: The elements between 10.1.2.0 and 10.1.2.255 are supplied by more than one range.",
+				"1,3: The elements between 192.168.0.0 and 192.168.0.255 are supplied by more than one range.",
+				"2,4: The elements between 10.1.2.0 and 10.1.2.255 are supplied by more than one range.",
+			},
+		},
+		{
+			name: "ipv6 no overlapping happy path",
+			cidrs: []string{
+				"2001:db8::/64",
+				"2001:db8:0:1::/64",
+			},
+		},
+		{
+			name: "ipv6 no mask overlap",
+			cidrs: []string{
+				"2001:db8::/64",
+				"2001:db8:0:1::1",
+				"2001:db8:0:1::1/128",
+			},
+			overlaps: []string{
+				"1,2: The element 2001:db8:0:1::1 is supplied by more than one range.",
+			},
+		},
+		{
+			name: "mixed families are checked separately",
+			cidrs: []string{
+				"0.0.0.0/0",
+				"::/0",
+			},
+		},
+		{
+			name: "mixed families overlapping ipv4",
+			cidrs: []string{
+				"2001:db8::/64",
+				"10.0.0.0/8",
+				"10.1.0.0/16",
+			},
+			overlaps: []string{
+				"1,2: The elements between 10.1.0.0 and 10.1.255.255 are supplied by more than one range.",
+			},
+		},
+		{
+			name: "numeric ordering",
+			cidrs: []string{
+				"192.168.10.0/24",
+				"192.168.9.0/24",
+				"192.168.100.0/24",
+			},
+		},
+		{
+			name: "numeric ordering overlap",
+			cidrs: []string{
+				"192.168.10.0/24",
+				"192.168.9.0/24",
+				"192.168.9.128/25",
+			},
+			overlaps: []string{
+				"1,2: The elements between 192.168.9.128 and 192.168.9.255 are supplied by more than one range.",
+			},
+		},
+		{
+			name: "ipv6 numeric ordering overlap",
+			cidrs: []string{
+				"2001:db8::/32",
+				"2001:db8:1::/48",
+			},
+			overlaps: []string{
+				"0,1: The elements between 2001:db8:1:: and 2001:db8:1:ffff:ffff:ffff:ffff:ffff are supplied by more than one range.",
+			},
+		},
+		{
+			name: "invalid",
+			cidrs: []string{
+				"2001:db8::/64",
+				"2001:db8::/129",
+			},
+			err: "invalid CIDR address: 2001:db8::/129",
 		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
-			if err := CIDR(test.cidrs); test.err == "" {
+			overlaps, err := CIDR(test.cidrs)
+			if test.err == "" {
 				require.NoError(t, err, test.name)
 			} else {
 				require.NotNil(t, err, test.name)
 				require.Equal(t, test.err, err.Error(), test.name)
 			}
+			require.Equal(t, test.overlaps, formatOverlaps(overlaps), test.name)
 		})
 	}
 }
@@ -102,13 +198,143 @@ func TestNewCidrRange(t *testing.T) {
 			first:  "192.168.0.0",
 			second: "192.168.127.255",
 		},
+		{
+			name:   "ipv6 network boundary /64",
+			cidr:   "2001:db8::/64",
+			first:  "2001:db8::",
+			second: "2001:db8::ffff:ffff:ffff:ffff",
+		},
+		{
+			name:   "ipv6 non-network boundary /56",
+			cidr:   "2001:db8:0:42::1/56",
+			first:  "2001:db8::",
+			second: "2001:db8:0:ff:ffff:ffff:ffff:ffff",
+		},
+		{
+			name:   "ipv6 /128",
+			cidr:   "2001:db8::1/128",
+			first:  "2001:db8::1",
+			second: "2001:db8::1",
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			_, ipNet, err := net.ParseCIDR(test.cidr)
 			require.NoError(t, err, test.name)
 			op := newCidrOrderedPair(ipNet)
-			require.Equal(t, test.first, op.First(), test.name)
-			require.Equal(t, test.second, op.Last(), test.name)
+			require.Equal(t, test.first, op.First().String(), test.name)
+			require.Equal(t, test.second, op.Last().String(), test.name)
+		})
+	}
+}
+
+func TestCIDRWithin(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		cidr    string
+		parents []string
+		within  bool
+		err     string
+	}{
+		{
+			name:    "within",
+			cidr:    "10.0.1.0/24",
+			parents: []string{"10.0.0.0/16"},
+			within:  true,
+		},
+		{
+			name:    "equal",
+			cidr:    "10.0.0.0/16",
+			parents: []string{"10.0.0.0/16"},
+			within:  true,
+		},
+		{
+			name:    "within second parent",
+			cidr:    "10.1.0.1",
+			parents: []string{"10.0.0.0/16", "10.1.0.0/16"},
+			within:  true,
+		},
+		{
+			name:    "partially outside",
+			cidr:    "10.0.0.0/15",
+			parents: []string{"10.0.0.0/16"},
+		},
+		{
+			name:    "spans two parents",
+			cidr:    "10.0.0.0/15",
+			parents: []string{"10.0.0.0/16", "10.1.0.0/16"},
+		},
+		{
+			name:    "ipv6 within",
+			cidr:    "2001:db8:0:1::/64",
+			parents: []string{"10.0.0.0/8", "2001:db8::/56"},
+			within:  true,
+		},
+		{
+			name:    "different family",
+			cidr:    "::/0",
+			parents: []string{"0.0.0.0/0"},
+		},
+		{
+			name:    "invalid parent",
+			cidr:    "10.0.0.0/8",
+			parents: []string{"10.0.0.0/33"},
+			err:     "invalid CIDR address: 10.0.0.0/33",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			within, err := CIDRWithin(test.cidr, test.parents)
+			if test.err == "" {
+				require.NoError(t, err, test.name)
+			} else {
+				require.NotNil(t, err, test.name)
+				require.Equal(t, test.err, err.Error(), test.name)
+			}
+			require.Equal(t, test.within, within, test.name)
+		})
+	}
+}
+
+func TestCanonicalCIDR(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		cidr      string
+		canonical string
+		err       string
+	}{
+		{
+			name:      "network address",
+			cidr:      "10.0.0.0/24",
+			canonical: "10.0.0.0/24",
+		},
+		{
+			name:      "host address",
+			cidr:      "10.0.0.1/24",
+			canonical: "10.0.0.0/24",
+		},
+		{
+			name:      "no mask",
+			cidr:      "10.0.0.1",
+			canonical: "10.0.0.1/32",
+		},
+		{
+			name:      "ipv6",
+			cidr:      "2001:DB8:0:42::1/56",
+			canonical: "2001:db8::/56",
+		},
+		{
+			name: "invalid",
+			cidr: "10.0.0.0/33",
+			err:  "invalid CIDR address: 10.0.0.0/33",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			canonical, err := CanonicalCIDR(test.cidr)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.canonical, canonical)
 		})
 	}
 }
@@ -1,15 +1,13 @@
 package overlap
 
 import (
-	"strconv"
-
 	"github.com/frankgreco/terraform-helpers/internal/utils"
 )
 
 // IntSlice ensures there is no overlap.
 // Of course there is an easier way to do this
 // But i'm utilizing an existing helper.
-func IntSlice(items []int) error {
+func IntSlice(items []int) []utils.Overlap {
 	if len(items) < 2 {
 		return nil
 	}
@@ -17,7 +15,7 @@ func IntSlice(items []int) error {
 	var pairs []utils.OrderedPair
 	{
 		for _, item := range items {
-			pairs = append(pairs, utils.NewOrderedPair(strconv.Itoa(item), strconv.Itoa(item)))
+			pairs = append(pairs, utils.NewOrderedPair(utils.NewIntKey(int64(item)), utils.NewIntKey(int64(item))))
 		}
 	}
 
@@ -8,9 +8,9 @@ import (
 
 func TestIntSlice(t *testing.T) {
 	for _, test := range []struct {
-		name  string
-		slice []int
-		err   string
+		name     string
+		slice    []int
+		overlaps []string
 	}{
 		{
 			name:  "no overlapping happy path",
@@ -19,26 +19,48 @@ func TestIntSlice(t *testing.T) {
 		{
 			name:  "overlapping happy path (1/2)",
 			slice: []int{1, 1, 3, 4},
-			err:   "The element 1 is supplied by more than one range.",
+			overlaps: []string{
+				"0,1: The element 1 is supplied by more than one range.",
+			},
 		},
 		{
 			name:  "overlapping happy path (2/2)",
 			slice: []int{1, 2, 3, 4, 4},
-			err:   "The element 4 is supplied by more than one range.",
+			overlaps: []string{
+				"3,4: The element 4 is supplied by more than one range.",
+			},
+		},
+		{
+			name:  "numeric ordering",
+			slice: []int{9, 10, 100, -1},
+		},
+		{
+			name:  "numeric ordering overlap",
+			slice: []int{10, 9, 100, 10},
+			overlaps: []string{
+				"0,3: The element 10 is supplied by more than one range.",
+			},
 		},
 		{
 			name:  "overlapping happy path (start with unsorted)",
 			slice: []int{4, 3, 3, 1},
-			err:   "The element 3 is supplied by more than one range.",
+			overlaps: []string{
+				"1,2: The element 3 is supplied by more than one range.",
+			},
+		},
+		{
+			name:  "every overlap is reported",
+			slice: []int{7, 3, 7, 3, 7},
+			overlaps: []string{
+				"0,2: The element 7 is supplied by more than one range.",
+				"0,4: The element 7 is supplied by more than one range.",
+				"1,3: The element 3 is supplied by more than one range.",
+				"2,4: The element 7 is supplied by more than one range.",
+			},
 		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
-			if err := IntSlice(test.slice); test.err == "" {
-				require.NoError(t, err, test.name)
-			} else {
-				require.NotNil(t, err, test.name)
-				require.Equal(t, test.err, err.Error(), test.name)
-			}
+			require.Equal(t, test.overlaps, formatOverlaps(IntSlice(test.slice)), test.name)
 		})
 	}
 }
@@ -0,0 +1,24 @@
+package overlap
+
+import (
+	"math/big"
+
+	"github.com/frankgreco/terraform-helpers/internal/utils"
+)
+
+// NumberSlice ensures that no number appears more than once. Unlike
+// IntSlice, the numbers are compared with their full precision.
+func NumberSlice(items []*big.Float) []utils.Overlap {
+	if len(items) < 2 {
+		return nil
+	}
+
+	var pairs []utils.OrderedPair
+	{
+		for _, item := range items {
+			pairs = append(pairs, utils.NewOrderedPair(utils.NewNumberKey(item), utils.NewNumberKey(item)))
+		}
+	}
+
+	return utils.Overlaps(pairs)
+}
@@ -0,0 +1,43 @@
+package overlap
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumberSlice(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		slice    []string
+		overlaps []string
+	}{
+		{
+			name:  "no overlapping happy path",
+			slice: []string{"1.2", "1.7", "9", "10"},
+		},
+		{
+			name:  "overlapping happy path",
+			slice: []string{"10", "1.5", "10.0"},
+			overlaps: []string{
+				"0,2: The element 10 is supplied by more than one range.",
+			},
+		},
+		{
+			name:  "large numbers",
+			slice: []string{"123456789012345678901", "123456789012345678902"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var slice []*big.Float
+			for _, item := range test.slice {
+				f, _, err := big.ParseFloat(item, 10, 512, big.ToNearestEven)
+				require.NoError(t, err, test.name)
+				slice = append(slice, f)
+			}
+
+			require.Equal(t, test.overlaps, formatOverlaps(NumberSlice(slice)), test.name)
+		})
+	}
+}
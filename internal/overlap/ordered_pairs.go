@@ -4,7 +4,7 @@ import (
 	"github.com/frankgreco/terraform-helpers/internal/utils"
 )
 
-func OrderedPairs(items []utils.OrderedPair) error {
+func OrderedPairs(items []utils.OrderedPair) []utils.Overlap {
 	if len(items) < 2 {
 		return nil
 	}
@@ -0,0 +1,62 @@
+package overlap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/frankgreco/terraform-helpers/internal/utils"
+)
+
+const (
+	// MinPort and MaxPort are the bounds of a valid port (inclusive).
+	MinPort = 1
+	MaxPort = 65535
+)
+
+// ParsePortRange parses either a single port, e.g. "80", or an inclusive
+// range of ports, e.g. "8000-8080".
+func ParsePortRange(encoded string) (utils.OrderedPair, error) {
+	from, to := encoded, encoded
+	if i := strings.Index(encoded, "-"); i >= 0 {
+		from, to = encoded[:i], encoded[i+1:]
+	}
+
+	first, err := parsePort(from)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid port range %q: %s", encoded, err)
+	}
+	last, err := parsePort(to)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid port range %q: %s", encoded, err)
+	}
+	if first > last {
+		return nil, fmt.Errorf("Invalid port range %q: %d is greater than %d", encoded, first, last)
+	}
+
+	return utils.NewOrderedPair(utils.NewIntKey(first), utils.NewIntKey(last)), nil
+}
+
+// Port returns the range containing only the port.
+func Port(port int64) (utils.OrderedPair, error) {
+	if port < MinPort || port > MaxPort {
+		return nil, fmt.Errorf("Invalid port %d: must be between %d and %d", port, MinPort, MaxPort)
+	}
+	return utils.NewOrderedPair(utils.NewIntKey(port), utils.NewIntKey(port)), nil
+}
+
+func parsePort(encoded string) (int64, error) {
+	// strconv.ParseInt accepts a sign, e.g. "+80", which isn't part of a port.
+	if encoded == "" || strings.TrimLeft(encoded, "0123456789") != "" {
+		return 0, fmt.Errorf("%q is not a number", encoded)
+	}
+	port, err := strconv.ParseInt(encoded, 10, 64)
+	if err != nil {
+		// The digits are too many for an int64.
+		return 0, fmt.Errorf("%s must be between %d and %d", encoded, MinPort, MaxPort)
+	}
+	if port < MinPort || port > MaxPort {
+		return 0, fmt.Errorf("%d must be between %d and %d", port, MinPort, MaxPort)
+	}
+	return port, nil
+}
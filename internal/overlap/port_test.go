@@ -0,0 +1,92 @@
+package overlap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortRange(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		encoded     string
+		first, last string
+		err         string
+	}{
+		{
+			name:    "single port",
+			encoded: "80",
+			first:   "80",
+			last:    "80",
+		},
+		{
+			name:    "range",
+			encoded: "8000-8080",
+			first:   "8000",
+			last:    "8080",
+		},
+		{
+			name:    "full range",
+			encoded: "1-65535",
+			first:   "1",
+			last:    "65535",
+		},
+		{
+			name:    "zero",
+			encoded: "0",
+			err:     `Invalid port range "0": 0 must be between 1 and 65535`,
+		},
+		{
+			name:    "too large",
+			encoded: "80-65536",
+			err:     `Invalid port range "80-65536": 65536 must be between 1 and 65535`,
+		},
+		{
+			name:    "reversed",
+			encoded: "8080-8000",
+			err:     `Invalid port range "8080-8000": 8080 is greater than 8000`,
+		},
+		{
+			name:    "not a number",
+			encoded: "http",
+			err:     `Invalid port range "http": "http" is not a number`,
+		},
+		{
+			name:    "negative",
+			encoded: "-80",
+			err:     `Invalid port range "-80": "" is not a number`,
+		},
+		{
+			name:    "signed",
+			encoded: "+80",
+			err:     `Invalid port range "+80": "+80" is not a number`,
+		},
+		{
+			name:    "signed range",
+			encoded: "+80-+90",
+			err:     `Invalid port range "+80-+90": "+80" is not a number`,
+		},
+		{
+			name:    "too many digits",
+			encoded: "99999999999999999999",
+			err:     `Invalid port range "99999999999999999999": 99999999999999999999 must be between 1 and 65535`,
+		},
+		{
+			name:    "missing upper bound",
+			encoded: "80-",
+			err:     `Invalid port range "80-": "" is not a number`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pair, err := ParsePortRange(test.encoded)
+			if test.err != "" {
+				require.NotNil(t, err, test.name)
+				require.Equal(t, test.err, err.Error(), test.name)
+				return
+			}
+			require.NoError(t, err, test.name)
+			require.Equal(t, test.first, pair.First().String(), test.name)
+			require.Equal(t, test.last, pair.Last().String(), test.name)
+		})
+	}
+}
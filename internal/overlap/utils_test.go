@@ -0,0 +1,15 @@
+package overlap
+
+import (
+	"fmt"
+
+	"github.com/frankgreco/terraform-helpers/internal/utils"
+)
+
+func formatOverlaps(overlaps []utils.Overlap) []string {
+	var formatted []string
+	for _, overlap := range overlaps {
+		formatted = append(formatted, fmt.Sprintf("%d,%d: %s", overlap.Left, overlap.Right, overlap.Error()))
+	}
+	return formatted
+}
@@ -0,0 +1,60 @@
+package parse
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	isoDurationRegex = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+	isoCalendarRegex = regexp.MustCompile(`^P[^T]*[YM]`)
+)
+
+// Duration parses either a Go duration, e.g. "1h30m", or an ISO 8601
+// duration, e.g. "PT1H30M". ISO 8601 durations may only use weeks, days,
+// hours, minutes and seconds, where a day is always 24 hours, because years
+// and months do not have a fixed length.
+func Duration(encoded string) (time.Duration, error) {
+	if !strings.HasPrefix(encoded, "P") {
+		d, err := time.ParseDuration(encoded)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid duration %q: must be a Go duration (e.g. \"1h30m\") or an ISO 8601 duration (e.g. \"PT1H30M\")", encoded)
+		}
+		return d, nil
+	}
+
+	if isoCalendarRegex.MatchString(encoded) {
+		return 0, fmt.Errorf("Invalid duration %q: years and months do not have a fixed length", encoded)
+	}
+
+	matches := isoDurationRegex.FindStringSubmatch(encoded)
+	if matches == nil || encoded == "P" || strings.HasSuffix(encoded, "T") {
+		return 0, fmt.Errorf("Invalid duration %q: must be an ISO 8601 duration (e.g. \"PT1H30M\")", encoded)
+	}
+
+	var total time.Duration
+	for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute} {
+		if matches[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(matches[i+1], 10, 64)
+		if err != nil || n > int64(math.MaxInt64-total)/int64(unit) {
+			return 0, fmt.Errorf("Invalid duration %q: too large", encoded)
+		}
+		total += time.Duration(n) * unit
+	}
+
+	if matches[5] != "" {
+		seconds, err := time.ParseDuration(strings.Replace(matches[5], ",", ".", 1) + "s")
+		if err != nil || seconds > math.MaxInt64-total {
+			return 0, fmt.Errorf("Invalid duration %q: too large", encoded)
+		}
+		total += seconds
+	}
+
+	return total, nil
+}
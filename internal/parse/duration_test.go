@@ -0,0 +1,83 @@
+package parse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuration(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		encoded  string
+		expected time.Duration
+		err      string
+	}{
+		{
+			name:     "go",
+			encoded:  "1h30m",
+			expected: 90 * time.Minute,
+		},
+		{
+			name:     "go seconds",
+			encoded:  "30s",
+			expected: 30 * time.Second,
+		},
+		{
+			name:     "iso time",
+			encoded:  "PT1H30M",
+			expected: 90 * time.Minute,
+		},
+		{
+			name:     "iso weeks and days",
+			encoded:  "P1W2DT3H",
+			expected: 9*24*time.Hour + 3*time.Hour,
+		},
+		{
+			name:     "iso fractional seconds",
+			encoded:  "PT0,5S",
+			expected: 500 * time.Millisecond,
+		},
+		{
+			name:    "iso years",
+			encoded: "P1Y",
+			err:     `Invalid duration "P1Y": years and months do not have a fixed length`,
+		},
+		{
+			name:    "iso months",
+			encoded: "P2M1D",
+			err:     `Invalid duration "P2M1D": years and months do not have a fixed length`,
+		},
+		{
+			name:    "iso empty",
+			encoded: "P",
+			err:     `Invalid duration "P": must be an ISO 8601 duration (e.g. "PT1H30M")`,
+		},
+		{
+			name:    "iso empty time",
+			encoded: "P1DT",
+			err:     `Invalid duration "P1DT": must be an ISO 8601 duration (e.g. "PT1H30M")`,
+		},
+		{
+			name:    "iso too large",
+			encoded: "P100000000W",
+			err:     `Invalid duration "P100000000W": too large`,
+		},
+		{
+			name:    "invalid",
+			encoded: "1 hour",
+			err:     `Invalid duration "1 hour": must be a Go duration (e.g. "1h30m") or an ISO 8601 duration (e.g. "PT1H30M")`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := Duration(test.encoded)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, d)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package parse
+
+import (
+	"fmt"
+	"time"
+)
+
+// Timestamp parses an RFC 3339 timestamp, e.g. "2022-01-02T15:04:05Z".
+func Timestamp(encoded string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, encoded)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid timestamp %q: must be an RFC 3339 timestamp (e.g. \"2022-01-02T15:04:05Z\")", encoded)
+	}
+	return t, nil
+}
@@ -0,0 +1,130 @@
+package parse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	versionRegex    = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+	identifierRegex = regexp.MustCompile(`^(0|[1-9]\d*)$`)
+)
+
+// Version is a semantic version as described by https://semver.org.
+type Version struct {
+	Major, Minor, Patch uint64
+	Prerelease          []string
+	Build               []string
+}
+
+// SemVer parses a semantic version, e.g. "1.2.3-rc.1+build.5",
+// optionally prefixed with a "v".
+func SemVer(encoded string) (Version, error) {
+	matches := versionRegex.FindStringSubmatch(encoded)
+	if matches == nil {
+		return Version{}, fmt.Errorf("Invalid version %q: must be a semantic version (e.g. \"1.2.3\")", encoded)
+	}
+
+	var v Version
+	for i, n := range []*uint64{&v.Major, &v.Minor, &v.Patch} {
+		number, err := strconv.ParseUint(matches[i+1], 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("Invalid version %q: %s is too large", encoded, matches[i+1])
+		}
+		*n = number
+	}
+
+	if matches[4] != "" {
+		v.Prerelease = strings.Split(matches[4], ".")
+		for _, identifier := range v.Prerelease {
+			if isNumeric(identifier) && !identifierRegex.MatchString(identifier) {
+				return Version{}, fmt.Errorf("Invalid version %q: the numeric identifier %s must not have leading zeros", encoded, identifier)
+			}
+		}
+	}
+	if matches[5] != "" {
+		v.Build = strings.Split(matches[5], ".")
+	}
+
+	return v, nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether v has a lower, equal or
+// higher precedence than other. Build metadata does not affect precedence.
+func (v Version) Compare(other Version) int {
+	for _, pair := range [][2]uint64{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if c := compareUint(pair[0], pair[1]); c != 0 {
+			return c
+		}
+	}
+
+	// A pre-release version has a lower precedence than the normal version.
+	switch {
+	case len(v.Prerelease) == 0 && len(other.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(other.Prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.Prerelease) && i < len(other.Prerelease); i++ {
+		if c := compareIdentifier(v.Prerelease[i], other.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(v.Prerelease)), uint64(len(other.Prerelease)))
+}
+
+// String returns the version without any "v" prefix.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if len(v.Build) > 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+	return s
+}
+
+// compareIdentifier compares pre-release identifiers. Numeric identifiers
+// are compared numerically and have a lower precedence than alphanumeric
+// identifiers, which are compared lexically.
+func compareIdentifier(a, b string) int {
+	aNumeric, bNumeric := isNumeric(a), isNumeric(b)
+	switch {
+	case aNumeric && bNumeric:
+		// Leading zeros are forbidden, so the longer number is the larger one.
+		if c := compareUint(uint64(len(a)), uint64(len(b))); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
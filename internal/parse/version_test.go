@@ -0,0 +1,91 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemVer(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		encoded  string
+		expected string
+		err      string
+	}{
+		{
+			name:     "release",
+			encoded:  "1.2.3",
+			expected: "1.2.3",
+		},
+		{
+			name:     "prefixed",
+			encoded:  "v1.2.3",
+			expected: "1.2.3",
+		},
+		{
+			name:     "prerelease and build",
+			encoded:  "1.0.0-rc.1+build.5",
+			expected: "1.0.0-rc.1+build.5",
+		},
+		{
+			name:    "missing patch",
+			encoded: "1.2",
+			err:     `Invalid version "1.2": must be a semantic version (e.g. "1.2.3")`,
+		},
+		{
+			name:    "leading zero",
+			encoded: "01.2.3",
+			err:     `Invalid version "01.2.3": must be a semantic version (e.g. "1.2.3")`,
+		},
+		{
+			name:    "leading zero in prerelease",
+			encoded: "1.2.3-rc.01",
+			err:     `Invalid version "1.2.3-rc.01": the numeric identifier 01 must not have leading zeros`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			v, err := SemVer(test.encoded)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, v.String())
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	// In order of precedence, as listed by https://semver.org.
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+		"10.0.0",
+	}
+
+	for i := range ordered {
+		for j := range ordered {
+			a, err := SemVer(ordered[i])
+			require.NoError(t, err)
+			b, err := SemVer(ordered[j])
+			require.NoError(t, err)
+
+			expected := compareUint(uint64(i), uint64(j))
+			require.Equal(t, expected, a.Compare(b), "%s compared to %s", ordered[i], ordered[j])
+		}
+	}
+
+	a, _ := SemVer("1.0.0+build.1")
+	b, _ := SemVer("v1.0.0+build.2")
+	require.Equal(t, 0, a.Compare(b))
+}
@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"net"
+	"strconv"
+	"time"
+)
+
+// Key is a point in an ordered domain, such as an integer or an IP address.
+type Key interface {
+	// Compare returns -1, 0 or +1 depending on whether the key is less than,
+	// equal to or greater than other. Keys of different kinds are ordered by
+	// kind so that they never compare as equal.
+	Compare(other Key) int
+
+	// String returns the human readable form of the key.
+	String() string
+}
+
+// DiscreteKey is a Key with well defined neighbours, such as an integer.
+type DiscreteKey interface {
+	Key
+
+	// Next returns the key immediately after this one. ok is false
+	// if this is the greatest key.
+	Next() (next Key, ok bool)
+
+	// Prev returns the key immediately before this one. ok is false
+	// if this is the least key.
+	Prev() (prev Key, ok bool)
+}
+
+// The relative order of keys of different kinds.
+const (
+	kindNumber = iota
+	kindIPv4
+	kindIPv6
+	kindTime
+)
+
+type intKey int64
+
+// NewIntKey returns a Key for an integer.
+func NewIntKey(i int64) Key {
+	return intKey(i)
+}
+
+func (k intKey) Compare(other Key) int {
+	switch o := other.(type) {
+	case intKey:
+		switch {
+		case k < o:
+			return -1
+		case k > o:
+			return 1
+		}
+		return 0
+	case numberKey:
+		return new(big.Float).SetInt64(int64(k)).Cmp(o.value)
+	}
+	return compareKind(kindNumber, other)
+}
+
+func (k intKey) Next() (Key, bool) {
+	if k == math.MaxInt64 {
+		return k, false
+	}
+	return k + 1, true
+}
+
+func (k intKey) Prev() (Key, bool) {
+	if k == math.MinInt64 {
+		return k, false
+	}
+	return k - 1, true
+}
+
+func (k intKey) String() string {
+	return strconv.FormatInt(int64(k), 10)
+}
+
+type numberKey struct {
+	value *big.Float
+}
+
+// NewNumberKey returns a Key for an arbitrary precision number.
+func NewNumberKey(f *big.Float) Key {
+	return numberKey{
+		value: f,
+	}
+}
+
+func (k numberKey) Compare(other Key) int {
+	switch o := other.(type) {
+	case intKey:
+		return k.value.Cmp(new(big.Float).SetInt64(int64(o)))
+	case numberKey:
+		return k.value.Cmp(o.value)
+	}
+	return compareKind(kindNumber, other)
+}
+
+func (k numberKey) String() string {
+	return k.value.Text('g', -1)
+}
+
+type ipKey struct {
+	ip net.IP
+}
+
+// NewIPKey returns a Key for an IPv4 or IPv6 address. IPv4 addresses
+// are ordered before all IPv6 addresses.
+func NewIPKey(ip net.IP) Key {
+	if v4 := ip.To4(); v4 != nil {
+		return ipKey{ip: v4}
+	}
+	return ipKey{ip: ip.To16()}
+}
+
+func (k ipKey) Compare(other Key) int {
+	o, ok := other.(ipKey)
+	if !ok {
+		return compareKind(kindOf(k), other)
+	}
+	if len(k.ip) != len(o.ip) {
+		if len(k.ip) < len(o.ip) {
+			return -1
+		}
+		return 1
+	}
+	return bytes.Compare(k.ip, o.ip)
+}
+
+// Next returns the following address of the same family.
+func (k ipKey) Next() (Key, bool) {
+	next := make(net.IP, len(k.ip))
+	copy(next, k.ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return ipKey{ip: next}, true
+		}
+	}
+	return k, false
+}
+
+// Prev returns the preceding address of the same family.
+func (k ipKey) Prev() (Key, bool) {
+	prev := make(net.IP, len(k.ip))
+	copy(prev, k.ip)
+	for i := len(prev) - 1; i >= 0; i-- {
+		prev[i]--
+		if prev[i] != 255 {
+			return ipKey{ip: prev}, true
+		}
+	}
+	return k, false
+}
+
+func (k ipKey) String() string {
+	return k.ip.String()
+}
+
+type timeKey struct {
+	t time.Time
+}
+
+// NewTimeKey returns a Key for an instant in time.
+func NewTimeKey(t time.Time) Key {
+	return timeKey{t: t}
+}
+
+func (k timeKey) Compare(other Key) int {
+	o, ok := other.(timeKey)
+	if !ok {
+		return compareKind(kindTime, other)
+	}
+	switch {
+	case k.t.Before(o.t):
+		return -1
+	case k.t.After(o.t):
+		return 1
+	}
+	return 0
+}
+
+func (k timeKey) String() string {
+	return k.t.Format(time.RFC3339Nano)
+}
+
+// SameKind reports whether the keys are of the same kind, e.g. both numbers
+// or both IPv4 addresses.
+func SameKind(a, b Key) bool {
+	return kindOf(a) == kindOf(b)
+}
+
+func kindOf(k Key) int {
+	switch k := k.(type) {
+	case ipKey:
+		if len(k.ip) == net.IPv4len {
+			return kindIPv4
+		}
+		return kindIPv6
+	case timeKey:
+		return kindTime
+	}
+	return kindNumber
+}
+
+func compareKind(kind int, other Key) int {
+	otherKind := kindOf(other)
+
+	switch {
+	case kind < otherKind:
+		return -1
+	case kind > otherKind:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyCompare(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		left, right Key
+		expected    int
+	}{
+		{
+			name:     "int less than",
+			left:     NewIntKey(9),
+			right:    NewIntKey(10),
+			expected: -1,
+		},
+		{
+			name:     "int equal",
+			left:     NewIntKey(10),
+			right:    NewIntKey(10),
+			expected: 0,
+		},
+		{
+			name:     "number greater than",
+			left:     NewNumberKey(big.NewFloat(1.7)),
+			right:    NewNumberKey(big.NewFloat(1.2)),
+			expected: 1,
+		},
+		{
+			name:     "int and number",
+			left:     NewIntKey(2),
+			right:    NewNumberKey(big.NewFloat(1.5)),
+			expected: 1,
+		},
+		{
+			name:     "ipv4 less than",
+			left:     NewIPKey(net.ParseIP("192.168.9.0")),
+			right:    NewIPKey(net.ParseIP("192.168.10.0")),
+			expected: -1,
+		},
+		{
+			name:     "ipv4 4 and 16 byte forms are equal",
+			left:     NewIPKey(net.ParseIP("10.0.0.1").To4()),
+			right:    NewIPKey(net.ParseIP("10.0.0.1")),
+			expected: 0,
+		},
+		{
+			name:     "ipv6 greater than",
+			left:     NewIPKey(net.ParseIP("2001:db8:ffff::")),
+			right:    NewIPKey(net.ParseIP("2001:db8:1::")),
+			expected: 1,
+		},
+		{
+			name:     "ipv4 before ipv6",
+			left:     NewIPKey(net.ParseIP("255.255.255.255")),
+			right:    NewIPKey(net.ParseIP("::")),
+			expected: -1,
+		},
+		{
+			name:     "numbers before ips",
+			left:     NewIntKey(1 << 40),
+			right:    NewIPKey(net.ParseIP("0.0.0.0")),
+			expected: -1,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, test.left.Compare(test.right), test.name)
+			require.Equal(t, -test.expected, test.right.Compare(test.left), test.name)
+		})
+	}
+}
+
+func TestSameKind(t *testing.T) {
+	require.True(t, SameKind(NewIntKey(1), NewNumberKey(big.NewFloat(1.5))))
+	require.True(t, SameKind(NewIPKey(net.ParseIP("10.0.0.1")), NewIPKey(net.ParseIP("::ffff:10.0.0.2"))))
+	require.True(t, SameKind(NewIPKey(net.ParseIP("::1")), NewIPKey(net.ParseIP("fe80::1"))))
+	require.False(t, SameKind(NewIPKey(net.ParseIP("10.0.0.1")), NewIPKey(net.ParseIP("::1"))))
+	require.False(t, SameKind(NewIntKey(1), NewIPKey(net.ParseIP("10.0.0.1"))))
+}
+
+func TestIPKeyNeighbours(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		ip         string
+		prev, next string
+	}{
+		{
+			name: "ipv4",
+			ip:   "10.0.0.255",
+			prev: "10.0.0.254",
+			next: "10.0.1.0",
+		},
+		{
+			name: "ipv4 least",
+			ip:   "0.0.0.0",
+			next: "0.0.0.1",
+		},
+		{
+			name: "ipv4 greatest",
+			ip:   "255.255.255.255",
+			prev: "255.255.255.254",
+		},
+		{
+			name: "ipv6",
+			ip:   "2001:db8::",
+			prev: "2001:db7:ffff:ffff:ffff:ffff:ffff:ffff",
+			next: "2001:db8::1",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			key := NewIPKey(net.ParseIP(test.ip)).(DiscreteKey)
+
+			prev, ok := key.Prev()
+			require.Equal(t, test.prev != "", ok, test.name)
+			if ok {
+				require.Equal(t, test.prev, prev.String(), test.name)
+			}
+
+			next, ok := key.Next()
+			require.Equal(t, test.next != "", ok, test.name)
+			if ok {
+				require.Equal(t, test.next, next.String(), test.name)
+			}
+		})
+	}
+}
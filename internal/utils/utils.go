@@ -6,42 +6,166 @@ import (
 )
 
 type OrderedPair interface {
-	First() string
-	Last() string
+	First() Key
+	Last() Key
 }
 
 type orderedPair struct {
-	first, last string
+	first, last Key
 }
 
-func (op orderedPair) First() string {
+func (op orderedPair) First() Key {
 	return op.first
 }
 
-func (op orderedPair) Last() string {
+func (op orderedPair) Last() Key {
 	return op.last
 }
 
-func NewOrderedPair(first, last string) OrderedPair {
+func NewOrderedPair(first, last Key) OrderedPair {
 	return orderedPair{
 		first: first,
 		last:  last,
 	}
 }
 
-func Overlaps(items []OrderedPair) error {
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].First() < items[j].First()
+// Contains reports whether every element supplied by inner is
+// also supplied by outer.
+func Contains(outer, inner OrderedPair) bool {
+	return outer.First().Compare(inner.First()) <= 0 && inner.Last().Compare(outer.Last()) <= 0
+}
+
+// Intersects reports whether at least one element is supplied by both a and b.
+func Intersects(a, b OrderedPair) bool {
+	return a.First().Compare(b.Last()) <= 0 && b.First().Compare(a.Last()) <= 0
+}
+
+// Gaps returns the ranges within span that are not supplied by any of the
+// items, in ascending order. The keys of span and items must be discrete.
+func Gaps(span OrderedPair, items []OrderedPair) ([]OrderedPair, error) {
+	sorted := make([]OrderedPair, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].First().Compare(sorted[j].First()) < 0
+	})
+
+	var gaps []OrderedPair
+
+	// cursor is the least element in span that has not yet been accounted for.
+	cursor := span.First()
+	for _, item := range sorted {
+		if item.Last().Compare(cursor) < 0 {
+			continue
+		}
+		if item.First().Compare(span.Last()) > 0 {
+			break
+		}
+
+		if item.First().Compare(cursor) > 0 {
+			last, err := prev(item.First())
+			if err != nil {
+				return nil, err
+			}
+			gaps = append(gaps, NewOrderedPair(cursor, last))
+		}
+
+		if item.Last().Compare(span.Last()) >= 0 {
+			return gaps, nil
+		}
+		next, err := next(item.Last())
+		if err != nil {
+			return nil, err
+		}
+		cursor = next
+	}
+
+	return append(gaps, NewOrderedPair(cursor, span.Last())), nil
+}
+
+func next(k Key) (Key, error) {
+	discrete, ok := k.(DiscreteKey)
+	if !ok {
+		return nil, fmt.Errorf("The element %s does not have a well defined successor.", k)
+	}
+	// A key without a successor is the greatest key, in which case it is
+	// always greater than or equal to the end of the span being walked.
+	next, _ := discrete.Next()
+	return next, nil
+}
+
+func prev(k Key) (Key, error) {
+	discrete, ok := k.(DiscreteKey)
+	if !ok {
+		return nil, fmt.Errorf("The element %s does not have a well defined predecessor.", k)
+	}
+	// A key without a predecessor is the least key, in which case it is
+	// never greater than the start of the span being walked.
+	prev, _ := discrete.Prev()
+	return prev, nil
+}
+
+// Overlap describes two ranges that supply at least one common element.
+type Overlap struct {
+	// Left and Right are the indexes of the overlapping ranges in the
+	// slice given to Overlaps. Left is always less than Right.
+	Left, Right int
+
+	// First and Last bound the elements supplied by both ranges.
+	First, Last Key
+}
+
+func (o Overlap) Error() string {
+	if o.First.Compare(o.Last) == 0 {
+		return fmt.Sprintf("The element %s is supplied by more than one range.", o.First)
+	}
+	return fmt.Sprintf("The elements between %s and %s are supplied by more than one range.", o.First, o.Last)
+}
+
+// Overlaps returns every pair of items that overlap, ordered by their
+// indexes. The items themselves are not reordered.
+func Overlaps(items []OrderedPair) []Overlap {
+	sorted := make([]int, len(items))
+	for i := range sorted {
+		sorted[i] = i
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return items[sorted[i]].First().Compare(items[sorted[j]].First()) < 0
 	})
 
-	for i := 1; i < len(items); i++ {
-		if l, f := items[i-1].Last(), items[i].First(); l >= f {
-			if l == f {
-				return fmt.Errorf("The element %s is supplied by more than one range.", f)
+	var overlaps []Overlap
+	for i := 1; i < len(sorted); i++ {
+		current := items[sorted[i]]
+
+		// Every earlier item starts at or before the current one,
+		// so they overlap whenever they end at or after it starts.
+		for j := 0; j < i; j++ {
+			previous := items[sorted[j]]
+			if previous.Last().Compare(current.First()) < 0 {
+				continue
+			}
+
+			overlap := Overlap{
+				Left:  sorted[j],
+				Right: sorted[i],
+				First: current.First(),
+				Last:  current.Last(),
 			}
-			return fmt.Errorf("The elements between %s and %s are supplied by more than one range.", f, l)
+			if previous.Last().Compare(current.Last()) < 0 {
+				overlap.Last = previous.Last()
+			}
+			if overlap.Left > overlap.Right {
+				overlap.Left, overlap.Right = overlap.Right, overlap.Left
+			}
+			overlaps = append(overlaps, overlap)
 		}
 	}
 
-	return nil
+	sort.Slice(overlaps, func(i, j int) bool {
+		if overlaps[i].Left != overlaps[j].Left {
+			return overlaps[i].Left < overlaps[j].Left
+		}
+		return overlaps[i].Right < overlaps[j].Right
+	})
+
+	return overlaps
 }
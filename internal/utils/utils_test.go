@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaps(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		span  [2]int64
+		items [][2]int64
+		gaps  []string
+	}{
+		{
+			name:  "exactly covered",
+			span:  [2]int64{0, 1023},
+			items: [][2]int64{{512, 1023}, {0, 511}},
+		},
+		{
+			name:  "covered with overlap and overflow",
+			span:  [2]int64{0, 10},
+			items: [][2]int64{{-5, 4}, {3, 7}, {8, 20}},
+		},
+		{
+			name:  "empty",
+			span:  [2]int64{0, 10},
+			items: nil,
+			gaps:  []string{"0-10"},
+		},
+		{
+			name:  "gaps at both ends and between",
+			span:  [2]int64{0, 1023},
+			items: [][2]int64{{600, 1000}, {10, 99}, {101, 500}},
+			gaps:  []string{"0-9", "100-100", "501-599", "1001-1023"},
+		},
+		{
+			name:  "items outside the span",
+			span:  [2]int64{10, 20},
+			items: [][2]int64{{0, 5}, {25, 30}},
+			gaps:  []string{"10-20"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var items []OrderedPair
+			for _, item := range test.items {
+				items = append(items, NewOrderedPair(NewIntKey(item[0]), NewIntKey(item[1])))
+			}
+
+			gaps, err := Gaps(NewOrderedPair(NewIntKey(test.span[0]), NewIntKey(test.span[1])), items)
+			require.NoError(t, err, test.name)
+
+			var formatted []string
+			for _, gap := range gaps {
+				formatted = append(formatted, gap.First().String()+"-"+gap.Last().String())
+			}
+			require.Equal(t, test.gaps, formatted, test.name)
+		})
+	}
+}
+
+func TestGapsNotDiscrete(t *testing.T) {
+	_, err := Gaps(
+		NewOrderedPair(NewNumberKey(big.NewFloat(0)), NewNumberKey(big.NewFloat(10))),
+		[]OrderedPair{
+			NewOrderedPair(NewNumberKey(big.NewFloat(2.5)), NewNumberKey(big.NewFloat(5))),
+		},
+	)
+	require.EqualError(t, err, "The element 2.5 does not have a well defined predecessor.")
+}
@@ -0,0 +1,295 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/frankgreco/terraform-helpers/internal/overlap"
+	"github.com/frankgreco/terraform-helpers/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+const (
+	addressClassInErr            = "%q must only contain %s addresses."
+	addressClassNotInErr         = "%q must not contain %s addresses."
+	addressClassInDescription    = "Ensures that the IP address or CIDR, or every one in the list, only contains addresses of the allowed classes."
+	addressClassNotInDescription = "Ensures that the IP address or CIDR, or every one in the list, does not contain addresses of the forbidden classes."
+)
+
+// AddressClass is a class of IPv4 and IPv6 addresses.
+type AddressClass int
+
+const (
+	AddressClassUnknown AddressClass = iota
+	// AddressClassPrivate is the RFC 1918 private IPv4 space and the RFC 4193 unique local IPv6 space.
+	AddressClassPrivate
+	// AddressClassShared is the RFC 6598 shared address space (100.64.0.0/10) used for carrier grade NAT.
+	AddressClassShared
+	// AddressClassPublic is every globally routable unicast address, i.e. those not special purpose or multicast.
+	AddressClassPublic
+	AddressClassLoopback
+	AddressClassLinkLocal
+	AddressClassMulticast
+	// AddressClassDocumentation is the space reserved for use in documentation (e.g. 192.0.2.0/24 and 2001:db8::/32).
+	AddressClassDocumentation
+	// AddressClassUnspecified is the unspecified address of either family (0.0.0.0 and ::).
+	AddressClassUnspecified
+	// AddressClassSpecialPurpose is every entry in the IANA IPv4 and IPv6 special-purpose address registries.
+	AddressClassSpecialPurpose
+)
+
+func (c AddressClass) String() string {
+	switch c {
+	case AddressClassPrivate:
+		return "private"
+	case AddressClassShared:
+		return "shared"
+	case AddressClassPublic:
+		return "public"
+	case AddressClassLoopback:
+		return "loopback"
+	case AddressClassLinkLocal:
+		return "link-local"
+	case AddressClassMulticast:
+		return "multicast"
+	case AddressClassDocumentation:
+		return "documentation"
+	case AddressClassUnspecified:
+		return "unspecified"
+	case AddressClassSpecialPurpose:
+		return "special-purpose"
+	}
+	return "unknown"
+}
+
+var (
+	privateNetworks       = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"}
+	sharedNetworks        = []string{"100.64.0.0/10"}
+	loopbackNetworks      = []string{"127.0.0.0/8", "::1/128"}
+	linkLocalNetworks     = []string{"169.254.0.0/16", "fe80::/10"}
+	multicastNetworks     = []string{"224.0.0.0/4", "ff00::/8"}
+	documentationNetworks = []string{"192.0.2.0/24", "198.51.100.0/24", "203.0.113.0/24", "2001:db8::/32", "3fff::/20"}
+	unspecifiedNetworks   = []string{"0.0.0.0/32", "::/128"}
+
+	// The IPv4-mapped IPv6 space (::ffff:0:0/96) is omitted as those
+	// addresses are treated as the IPv4 addresses they represent.
+	specialPurposeNetworks = []string{
+		"0.0.0.0/8",
+		"10.0.0.0/8",
+		"100.64.0.0/10",
+		"127.0.0.0/8",
+		"169.254.0.0/16",
+		"172.16.0.0/12",
+		"192.0.0.0/24",
+		"192.0.2.0/24",
+		"192.31.196.0/24",
+		"192.52.193.0/24",
+		"192.88.99.0/24",
+		"192.168.0.0/16",
+		"192.175.48.0/24",
+		"198.18.0.0/15",
+		"198.51.100.0/24",
+		"203.0.113.0/24",
+		"240.0.0.0/4",
+		"255.255.255.255/32",
+		"::/128",
+		"::1/128",
+		"64:ff9b::/96",
+		"64:ff9b:1::/48",
+		"100::/64",
+		"2001::/23",
+		"2001:db8::/32",
+		"2002::/16",
+		"2620:4f:8000::/48",
+		"3fff::/20",
+		"fc00::/7",
+		"fe80::/10",
+	}
+
+	addressClasses = map[AddressClass][]utils.OrderedPair{
+		AddressClassPrivate:        mustParseNetworks(privateNetworks...),
+		AddressClassShared:         mustParseNetworks(sharedNetworks...),
+		AddressClassPublic:         publicNetworks(),
+		AddressClassLoopback:       mustParseNetworks(loopbackNetworks...),
+		AddressClassLinkLocal:      mustParseNetworks(linkLocalNetworks...),
+		AddressClassMulticast:      mustParseNetworks(multicastNetworks...),
+		AddressClassDocumentation:  mustParseNetworks(documentationNetworks...),
+		AddressClassUnspecified:    mustParseNetworks(unspecifiedNetworks...),
+		AddressClassSpecialPurpose: mustParseNetworks(specialPurposeNetworks...),
+	}
+)
+
+func mustParseNetworks(networks ...string) []utils.OrderedPair {
+	pairs := make([]utils.OrderedPair, len(networks))
+	for i, network := range networks {
+		pair, err := overlap.ParseCIDR(network)
+		if err != nil {
+			panic(err)
+		}
+		pairs[i] = pair
+	}
+	return pairs
+}
+
+// publicNetworks returns the IPv4 space and the IPv6 global unicast
+// space (2000::/3) that are neither special purpose nor multicast.
+func publicNetworks() []utils.OrderedPair {
+	reserved := mustParseNetworks(append(specialPurposeNetworks, multicastNetworks...)...)
+
+	var public []utils.OrderedPair
+	for _, span := range mustParseNetworks("0.0.0.0/0", "2000::/3") {
+		gaps, err := utils.Gaps(span, reserved)
+		if err != nil {
+			panic(err)
+		}
+		public = append(public, gaps...)
+	}
+	return public
+}
+
+// intersects reports whether any address in the range belongs to the class.
+func (c AddressClass) intersects(cidr utils.OrderedPair) bool {
+	for _, network := range addressClasses[c] {
+		if utils.Intersects(network, cidr) {
+			return true
+		}
+	}
+	return false
+}
+
+type addressClassValidator struct {
+	classes []AddressClass
+	allowed bool
+	err     error
+}
+
+// AddressClassIn ensures that the IP address or CIDR, or every one in the
+// list or set, only contains addresses belonging to the provided classes.
+func AddressClassIn(classes ...AddressClass) tfsdk.AttributeValidator {
+	return newAddressClassValidator(classes, true)
+}
+
+// AddressClassNotIn ensures that the IP address or CIDR, or every one in the
+// list or set, contains no addresses belonging to the provided classes.
+func AddressClassNotIn(classes ...AddressClass) tfsdk.AttributeValidator {
+	return newAddressClassValidator(classes, false)
+}
+
+func newAddressClassValidator(classes []AddressClass, allowed bool) addressClassValidator {
+	v := addressClassValidator{
+		classes: classes,
+		allowed: allowed,
+	}
+	if len(classes) == 0 {
+		v.err = errors.New("This validator was initialized without any address classes")
+	}
+	for _, class := range classes {
+		if _, ok := addressClasses[class]; !ok {
+			v.err = errors.New("This validator was initialized with an unknown address class")
+		}
+	}
+	return v
+}
+
+// Description describes this validator.
+func (v addressClassValidator) Description(context.Context) string {
+	if v.allowed {
+		return addressClassInDescription
+	}
+	return addressClassNotInDescription
+}
+
+// MarkdownDescription describes this validator.
+func (v addressClassValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// Validate performs validation on an attribute.
+func (v addressClassValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	if v.err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Address Class",
+			v.err.Error(),
+		)
+		return
+	}
+
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Address",
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	encoded, paths, known, err := stringElements(this, req.AttributePath)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Address",
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	// We don't need to do any validation if the value isn't "set".
+	if !known {
+		return
+	}
+
+	for i, address := range encoded {
+		cidr, err := overlap.ParseCIDR(address)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				paths[i],
+				"Invalid Address",
+				"value must be a valid IP address or CIDR",
+			)
+			continue
+		}
+
+		if v.allowed && !v.anyContains(cidr) {
+			resp.Diagnostics.AddAttributeError(
+				paths[i],
+				"Invalid Address",
+				fmt.Sprintf(addressClassInErr, address, v.classNames()),
+			)
+		}
+
+		if !v.allowed {
+			for _, class := range v.classes {
+				if class.intersects(cidr) {
+					resp.Diagnostics.AddAttributeError(
+						paths[i],
+						"Invalid Address",
+						fmt.Sprintf(addressClassNotInErr, address, class),
+					)
+				}
+			}
+		}
+	}
+}
+
+// anyContains reports whether every address in the range belongs to at
+// least one of the classes.
+func (v addressClassValidator) anyContains(cidr utils.OrderedPair) bool {
+	var networks []utils.OrderedPair
+	for _, class := range v.classes {
+		networks = append(networks, addressClasses[class]...)
+	}
+	gaps, err := utils.Gaps(cidr, networks)
+	return err == nil && len(gaps) == 0
+}
+
+func (v addressClassValidator) classNames() string {
+	names := make([]string, len(v.classes))
+	for i, class := range v.classes {
+		names[i] = class.String()
+	}
+	return strings.Join(names, " or ")
+}
@@ -0,0 +1,148 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAddressClassIn(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("cidrs")
+
+	for _, test := range []struct {
+		name    string
+		classes []AddressClass
+		value   string
+		err     bool
+	}{
+		{name: "private ipv4", classes: []AddressClass{AddressClassPrivate}, value: "10.1.0.0/16"},
+		{name: "private ipv6", classes: []AddressClass{AddressClassPrivate}, value: "fd00::/8"},
+		{name: "private ip", classes: []AddressClass{AddressClassPrivate}, value: "192.168.1.1"},
+		{name: "not private", classes: []AddressClass{AddressClassPrivate}, value: "8.8.8.8", err: true},
+		{name: "partially private", classes: []AddressClass{AddressClassPrivate}, value: "10.0.0.0/7", err: true},
+		{name: "private or shared", classes: []AddressClass{AddressClassPrivate, AddressClassShared}, value: "100.64.0.0/10"},
+		{name: "public ipv4", classes: []AddressClass{AddressClassPublic}, value: "8.8.8.0/24"},
+		{name: "public ipv6", classes: []AddressClass{AddressClassPublic}, value: "2600::/16"},
+		{name: "public ipv6 outside global unicast", classes: []AddressClass{AddressClassPublic}, value: "4000::/16", err: true},
+		{name: "public contains documentation", classes: []AddressClass{AddressClassPublic}, value: "203.0.0.0/16", err: true},
+		{name: "default route is not public", classes: []AddressClass{AddressClassPublic}, value: "0.0.0.0/0", err: true},
+		{name: "multicast is not public", classes: []AddressClass{AddressClassPublic}, value: "239.0.0.1", err: true},
+		{name: "loopback", classes: []AddressClass{AddressClassLoopback}, value: "::1"},
+		{name: "link-local", classes: []AddressClass{AddressClassLinkLocal}, value: "fe80::1"},
+		{name: "special purpose benchmarking", classes: []AddressClass{AddressClassSpecialPurpose}, value: "198.19.0.0/16"},
+		{name: "invalid", classes: []AddressClass{AddressClassPrivate}, value: "10.0.0.0/33", err: true},
+		{name: "unknown class", classes: []AddressClass{AddressClassUnknown}, value: "10.0.0.0/8", err: true},
+		{name: "no classes", value: "10.0.0.0/8", err: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testCase{
+				name:      test.name,
+				validator: AddressClassIn(test.classes...),
+				request: tfsdk.ValidateAttributeRequest{
+					AttributePath:   path,
+					AttributeConfig: types.String{Value: test.value},
+				},
+				err: test.err,
+			}.run(t)
+		})
+	}
+}
+
+func TestAddressClassNotIn(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("cidrs")
+
+	for _, test := range []testCase{
+		{
+			name:      "pass",
+			validator: AddressClassNotIn(AddressClassLoopback, AddressClassMulticast, AddressClassPublic),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("10.0.0.0/8", "fd12:3456::/32"),
+			},
+		},
+		{
+			name:      "fail",
+			validator: AddressClassNotIn(AddressClassLoopback, AddressClassPrivate, AddressClassDocumentation),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("8.8.8.8", "127.0.0.1", "0.0.0.0/0"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(1),
+					"Invalid Address",
+					`"127.0.0.1" must not contain loopback addresses.`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					"Invalid Address",
+					`"0.0.0.0/0" must not contain loopback addresses.`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					"Invalid Address",
+					`"0.0.0.0/0" must not contain private addresses.`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					"Invalid Address",
+					`"0.0.0.0/0" must not contain documentation addresses.`,
+				),
+			},
+		},
+		{
+			name:      "unspecified",
+			validator: AddressClassNotIn(AddressClassUnspecified),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Value: "::"},
+			},
+			err: true,
+		},
+		{
+			name:      "no classes",
+			validator: AddressClassNotIn(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: types.String{Value: "10.0.0.0/8"},
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					"Invalid Address Class",
+					"This validator was initialized without any address classes",
+				),
+			},
+		},
+		{
+			name:      "null",
+			validator: AddressClassNotIn(AddressClassPrivate),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Null: true},
+			},
+		},
+		{
+			name:      "unknown",
+			validator: AddressClassNotIn(AddressClassPrivate),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Unknown: true},
+			},
+		},
+		{
+			name:      "wrong type",
+			validator: AddressClassNotIn(AddressClassPrivate),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.Bool{Value: true},
+			},
+			err: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	aggregateErr = "The aggregate comparison failed."
+)
+
+// Aggregate is a function that reduces the values of an attribute
+// across every object in a list or set to a single number.
+type Aggregate int
+
+const (
+	AggregateUnknown Aggregate = iota
+	AggregateSum
+	AggregateMin
+	AggregateMax
+	AggregateCount
+)
+
+func (a Aggregate) String() string {
+	switch a {
+	case AggregateSum:
+		return "sum"
+	case AggregateMin:
+		return "minimum"
+	case AggregateMax:
+		return "maximum"
+	case AggregateCount:
+		return "count"
+	}
+	return "unknown aggregate"
+}
+
+type aggregateValidator struct {
+	aggregate  Aggregate
+	attribute  string
+	comparator Comparator
+	value      *big.Rat
+	err        error
+}
+
+func newAggregateValidator(aggregate Aggregate, attribute string, comparator Comparator, value float64) aggregateValidator {
+	v := aggregateValidator{
+		aggregate:  aggregate,
+		attribute:  attribute,
+		comparator: comparator,
+	}
+	// The value is compared as the shortest decimal that represents it,
+	// e.g. 0.3 rather than 0.299999999999999988897769753748434595763683319091796875.
+	if r, ok := new(big.Rat).SetString(strconv.FormatFloat(value, 'g', -1, 64)); ok {
+		v.value = r
+	} else {
+		v.err = fmt.Errorf("This validator was initialized with an invalid number: %v is not a finite number", value)
+	}
+	return v
+}
+
+// SumOf ensures that the comparison between the sum of the number attribute across
+// every object in the list or set and the value holds, e.g. SumOf("weight",
+// ComparatorEqual, 100). Objects for which the attribute is null are ignored.
+//
+// The numbers are summed and compared as the decimals they are written as, and
+// the value as the shortest decimal that represents it, so weights of 0.1 and
+// 0.2 sum to exactly 0.3.
+func SumOf(attribute string, comparator Comparator, value float64) tfsdk.AttributeValidator {
+	return newAggregateValidator(AggregateSum, attribute, comparator, value)
+}
+
+// MinOf is the same as SumOf, but for the smallest value of the attribute.
+// There is nothing to compare if the attribute is null for every object.
+func MinOf(attribute string, comparator Comparator, value float64) tfsdk.AttributeValidator {
+	return newAggregateValidator(AggregateMin, attribute, comparator, value)
+}
+
+// MaxOf is the same as SumOf, but for the largest value of the attribute.
+// There is nothing to compare if the attribute is null for every object.
+func MaxOf(attribute string, comparator Comparator, value float64) tfsdk.AttributeValidator {
+	return newAggregateValidator(AggregateMax, attribute, comparator, value)
+}
+
+// CountOf is the same as SumOf, but for the number of objects
+// for which the attribute is set (not null).
+func CountOf(attribute string, comparator Comparator, value float64) tfsdk.AttributeValidator {
+	return newAggregateValidator(AggregateCount, attribute, comparator, value)
+}
+
+// Description describes this validator.
+func (v aggregateValidator) Description(context.Context) string {
+	return fmt.Sprintf("Ensures that the comparison between the %s of %s and %s holds.", v.aggregate, v.attribute, v.formatValue())
+}
+
+// MarkdownDescription describes this validator.
+func (v aggregateValidator) MarkdownDescription(context.Context) string {
+	return fmt.Sprintf("Ensures that the comparison between the %s of `%s` and %s holds.", v.aggregate, v.attribute, v.formatValue())
+}
+
+func (v aggregateValidator) formatValue() string {
+	if v.value == nil {
+		return "an invalid number"
+	}
+	return formatDecimal(v.value)
+}
+
+// Validate performs validation on an attribute.
+func (v aggregateValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	if v.err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			aggregateErr,
+			v.err.Error(),
+		)
+		return
+	}
+
+	if v.comparator == ComparatorUnknown || v.aggregate == AggregateUnknown {
+		resp.Diagnostics.AddError(
+			aggregateErr,
+			"Unknown comparator or aggregate",
+		)
+		return
+	}
+
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			aggregateErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	// The aggregate can't be computed until every element is known.
+	if !this.IsFullyKnown() || this.IsNull() {
+		return
+	}
+
+	if !this.Type().Is(tftypes.List{}) && !this.Type().Is(tftypes.Set{}) {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			aggregateErr,
+			"Unsupported type. Only lists and sets of objects are supported.",
+		)
+		return
+	}
+
+	var elems []tftypes.Value
+	if err := this.As(&elems); err != nil {
+		resp.Diagnostics.AddError(
+			aggregateErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	var result *big.Rat
+	sum := new(big.Rat)
+	count := 0
+	for _, elem := range elems {
+		var attrs map[string]tftypes.Value
+		if !elem.Type().Is(tftypes.Object{}) || elem.As(&attrs) != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath,
+				aggregateErr,
+				"Unsupported type. Only lists and sets of objects are supported.",
+			)
+			return
+		}
+		if elem.IsNull() {
+			continue
+		}
+
+		value, ok := attrs[v.attribute]
+		if !ok || !value.Type().Is(tftypes.Number) {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath,
+				aggregateErr,
+				fmt.Sprintf("The objects must have the number attribute %s.", v.attribute),
+			)
+			return
+		}
+		if value.IsNull() {
+			continue
+		}
+
+		// A zero precision target takes on the precision of the value.
+		number := new(big.Float)
+		if err := value.As(number); err != nil {
+			resp.Diagnostics.AddError(
+				aggregateErr,
+				"The validator had an internal error: "+err.Error(),
+			)
+			return
+		}
+
+		if number.IsInf() {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath,
+				aggregateErr,
+				fmt.Sprintf("The %s of %s is infinite.", v.attribute, formatPath(req.AttributePath)),
+			)
+			return
+		}
+
+		// The number is taken to be the decimal it was written as, rather than its
+		// binary approximation, so that the arithmetic is exact, e.g. 0.1 + 0.2 = 0.3.
+		decimal, _ := new(big.Rat).SetString(formatNumber(number))
+		sum.Add(sum, decimal)
+		count++
+
+		switch {
+		case result == nil:
+			result = decimal
+		case v.aggregate == AggregateMin && decimal.Cmp(result) < 0:
+			result = decimal
+		case v.aggregate == AggregateMax && decimal.Cmp(result) > 0:
+			result = decimal
+		}
+	}
+
+	switch v.aggregate {
+	case AggregateCount:
+		result = new(big.Rat).SetInt64(int64(count))
+	case AggregateSum:
+		result = sum
+	}
+
+	// There is no minimum or maximum of nothing.
+	if result == nil {
+		return
+	}
+
+	if err := compareResult(result.Cmp(v.value), v.comparator, formatDecimal(result), formatDecimal(v.value)); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			aggregateErr,
+			fmt.Sprintf("The %s of %s across %s is invalid: %s.", v.aggregate, v.attribute, formatPath(req.AttributePath), err),
+		)
+	}
+}
+
+// formatDecimal returns the rational number as a decimal, e.g. 0.3. Numbers
+// without a finite decimal representation are rounded to 32 significant digits.
+func formatDecimal(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	// The number of decimal places is the larger of the powers of 2 and 5
+	// in the denominator, provided that they are its only factors.
+	d := new(big.Int).Set(r.Denom())
+	twos, fives := 0, 0
+	two, five := big.NewInt(2), big.NewInt(5)
+	mod := new(big.Int)
+	for d.Cmp(big.NewInt(1)) != 0 {
+		switch {
+		case mod.Mod(d, two).Sign() == 0:
+			d.Quo(d, two)
+			twos++
+		case mod.Mod(d, five).Sign() == 0:
+			d.Quo(d, five)
+			fives++
+		default:
+			return new(big.Float).SetPrec(256).SetRat(r).Text('g', 32)
+		}
+	}
+
+	places := twos
+	if fives > places {
+		places = fives
+	}
+	return r.FloatString(places)
+}
@@ -0,0 +1,221 @@
+package validators
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAggregate(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("targets")
+
+	weights := func(values ...types.Number) types.List {
+		objects := make([]map[string]attr.Value, len(values))
+		for i, value := range values {
+			objects[i] = map[string]attr.Value{"weight": value}
+		}
+		return objectList(objects...)
+	}
+	n := func(f float64) types.Number {
+		return types.Number{Value: big.NewFloat(f)}
+	}
+	precise := func(s string) types.Number {
+		f, _, _ := big.ParseFloat(s, 10, 512, big.ToNearestEven)
+		return types.Number{Value: f}
+	}
+	null := types.Number{Null: true}
+
+	for _, test := range []testCase{
+		{
+			name:      "sum pass",
+			validator: SumOf("weight", ComparatorEqual, 100),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(50), n(25.5), n(24.5), null),
+			},
+		},
+		{
+			name:      "sum fail",
+			validator: SumOf("weight", ComparatorEqual, 100),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(50), n(45)),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					aggregateErr,
+					"The sum of weight across targets is invalid: 95 is not equal to 100.",
+				),
+			},
+		},
+		{
+			name:      "decimal sum",
+			validator: SumOf("weight", ComparatorEqual, 0.3),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(0.1), n(0.2)),
+			},
+		},
+		{
+			name:      "decimal sum at most",
+			validator: SumOf("weight", ComparatorLessThanEqual, 0.3),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(0.1), n(0.2)),
+			},
+		},
+		{
+			name:      "decimal sum fail",
+			validator: SumOf("weight", ComparatorLessThan, 0.3),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(0.1), n(0.2)),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					aggregateErr,
+					"The sum of weight across targets is invalid: 0.3 is not less than 0.3.",
+				),
+			},
+		},
+		{
+			name:      "precise decimal sum",
+			validator: SumOf("weight", ComparatorEqual, 100),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(precise("33.33333333333333333333"), precise("66.66666666666666666667")),
+			},
+		},
+		{
+			name:      "decimal max",
+			validator: MaxOf("weight", ComparatorLessThanEqual, 0.7),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(0.7), n(0.3)),
+			},
+		},
+		{
+			name:      "invalid value",
+			validator: SumOf("weight", ComparatorEqual, math.NaN()),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(1)),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					aggregateErr,
+					"This validator was initialized with an invalid number: NaN is not a finite number",
+				),
+			},
+		},
+		{
+			name:      "sum of nothing",
+			validator: SumOf("weight", ComparatorGreaterThan, 0),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(null),
+			},
+			err: true,
+		},
+		{
+			name:      "min pass",
+			validator: MinOf("weight", ComparatorGreaterThanEqual, 1),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(3), n(1), n(2)),
+			},
+		},
+		{
+			name:      "min fail",
+			validator: MinOf("weight", ComparatorGreaterThanEqual, 1),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(3), n(0.5), n(2)),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					aggregateErr,
+					"The minimum of weight across targets is invalid: 0.5 is not greater than or equal to 1.",
+				),
+			},
+		},
+		{
+			name:      "min of nothing",
+			validator: MinOf("weight", ComparatorGreaterThanEqual, 1),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(null),
+			},
+		},
+		{
+			name:      "max fail",
+			validator: MaxOf("weight", ComparatorLessThanEqual, 100),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(3), n(101), n(2)),
+			},
+			err: true,
+		},
+		{
+			name:      "count pass",
+			validator: CountOf("weight", ComparatorEqual, 2),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(3), null, n(2)),
+			},
+		},
+		{
+			name:      "count fail",
+			validator: CountOf("weight", ComparatorLessThan, 2),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(3), null, n(2)),
+			},
+			err: true,
+		},
+		{
+			name:      "unknown",
+			validator: SumOf("weight", ComparatorEqual, 100),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(50), types.Number{Unknown: true}),
+			},
+		},
+		{
+			name:      "missing attribute",
+			validator: SumOf("percent", ComparatorEqual, 100),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: weights(n(100)),
+			},
+			err: true,
+		},
+		{
+			name:      "not a list of objects",
+			validator: SumOf("weight", ComparatorEqual, 100),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: numberList(100),
+			},
+			err: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/frankgreco/terraform-helpers/internal/overlap"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+const (
+	cidrWithinErr         = "%q is not within any of [%s]."
+	cidrWithinDescription = "Ensures that the CIDR, or every CIDR in the list, is fully contained in one of the allowed networks."
+)
+
+type cidrWithinValidator struct {
+	networks  []string
+	attribute string
+}
+
+// CidrWithin ensures that the CIDR, or every CIDR in the list or set,
+// is fully contained in at least one of the provided networks.
+func CidrWithin(networks ...string) tfsdk.AttributeValidator {
+	return cidrWithinValidator{
+		networks: networks,
+	}
+}
+
+// CidrWithinAttribute ensures that the CIDR, or every CIDR in the list or set,
+// is fully contained in at least one of the networks supplied by the specified
+// attribute. The attribute is a path expression, e.g. "vpc_cidr" at the same
+// level or "/vpc_cidr" from the root. That attribute may be either a CIDR or a
+// list or set of CIDRs.
+func CidrWithinAttribute(attribute string) tfsdk.AttributeValidator {
+	return cidrWithinValidator{
+		attribute: attribute,
+	}
+}
+
+// Description describes this validator.
+func (v cidrWithinValidator) Description(context.Context) string {
+	return cidrWithinDescription
+}
+
+// MarkdownDescription describes this validator.
+func (v cidrWithinValidator) MarkdownDescription(context.Context) string {
+	return cidrWithinDescription
+}
+
+// Validate performs validation on an attribute.
+func (v cidrWithinValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid CIDR",
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	cidrs, paths, known, err := stringElements(this, req.AttributePath)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid CIDR",
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	// We don't need to do any validation if the value isn't "set".
+	if !known || len(cidrs) == 0 {
+		return
+	}
+
+	networks := v.networks
+	if v.attribute != "" {
+		value, _, ok, diags := getAttribute(ctx, req, v.attribute)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() || !ok {
+			return
+		}
+
+		networks, _, known, err = stringElements(value, nil)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath,
+				"Invalid CIDR",
+				"The validator had an internal error: "+err.Error(),
+			)
+			return
+		}
+
+		// Check if the attribute is "actually" set.
+		if !known || len(networks) == 0 {
+			return
+		}
+	}
+
+	for _, network := range networks {
+		if _, err := overlap.ParseCIDR(network); err != nil {
+			// An invalid sibling is reported by that attribute's own validators.
+			if v.attribute == "" {
+				resp.Diagnostics.AddAttributeError(
+					req.AttributePath,
+					"Invalid Network",
+					"This validator was initialized with an invalid network: "+err.Error(),
+				)
+			}
+			return
+		}
+	}
+
+	for i, cidr := range cidrs {
+		within, err := overlap.CIDRWithin(cidr, networks)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				paths[i],
+				"Invalid CIDR",
+				err.Error(),
+			)
+			continue
+		}
+
+		if !within {
+			resp.Diagnostics.AddAttributeError(
+				paths[i],
+				"Invalid CIDR",
+				fmt.Sprintf(cidrWithinErr, cidr, strings.Join(networks, ", ")),
+			)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCidrWithin(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("subnet_cidrs")
+
+	for _, test := range []testCase{
+		{
+			name:      "string pass",
+			validator: CidrWithin("10.0.0.0/16"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Value: "10.0.1.0/24"},
+			},
+		},
+		{
+			name:      "string fail",
+			validator: CidrWithin("10.0.0.0/16", "10.2.0.0/16"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: types.String{Value: "10.1.0.0/24"},
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					"Invalid CIDR",
+					`"10.1.0.0/24" is not within any of [10.0.0.0/16, 10.2.0.0/16].`,
+				),
+			},
+		},
+		{
+			name:      "list fail",
+			validator: CidrWithin("10.0.0.0/16"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("10.0.1.0/24", "10.0.0.0/8", "10.0.2.0/24", "10.1.0.1"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(1),
+					"Invalid CIDR",
+					`"10.0.0.0/8" is not within any of [10.0.0.0/16].`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(3),
+					"Invalid CIDR",
+					`"10.1.0.1" is not within any of [10.0.0.0/16].`,
+				),
+			},
+		},
+		{
+			name:      "invalid network",
+			validator: CidrWithin("10.0.0.0/33"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Value: "10.0.1.0/24"},
+			},
+			err: true,
+		},
+		{
+			name:      "invalid cidr",
+			validator: CidrWithin("10.0.0.0/16"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Value: "10.0.1.0/33"},
+			},
+			err: true,
+		},
+		{
+			name:      "attribute pass",
+			validator: CidrWithinAttribute("vpc_cidr"),
+			request: testRequest("subnet_cidrs", map[string]attr.Value{
+				"vpc_cidr":     types.String{Value: "10.0.0.0/16"},
+				"subnet_cidrs": stringList("10.0.1.0/24", "10.0.2.0/24"),
+			}),
+		},
+		{
+			name:      "attribute list pass",
+			validator: CidrWithinAttribute("vpc_cidrs"),
+			request: testRequest("subnet_cidrs", map[string]attr.Value{
+				"vpc_cidrs":    stringList("10.0.0.0/16", "2001:db8::/56"),
+				"subnet_cidrs": stringList("10.0.1.0/24", "2001:db8:0:1::/64"),
+			}),
+		},
+		{
+			name:      "attribute fail",
+			validator: CidrWithinAttribute("vpc_cidr"),
+			request: testRequest("subnet_cidrs", map[string]attr.Value{
+				"vpc_cidr":     types.String{Value: "10.0.0.0/16"},
+				"subnet_cidrs": stringList("10.0.1.0/24", "10.1.2.0/24"),
+			}),
+			err: true,
+		},
+		{
+			name:      "attribute unknown",
+			validator: CidrWithinAttribute("vpc_cidr"),
+			request: testRequest("subnet_cidrs", map[string]attr.Value{
+				"vpc_cidr":     types.String{Unknown: true},
+				"subnet_cidrs": stringList("10.1.2.0/24"),
+			}),
+		},
+		{
+			name:      "attribute null",
+			validator: CidrWithinAttribute("vpc_cidr"),
+			request: testRequest("subnet_cidrs", map[string]attr.Value{
+				"vpc_cidr":     types.String{Null: true},
+				"subnet_cidrs": stringList("10.1.2.0/24"),
+			}),
+		},
+		{
+			name:      "null",
+			validator: CidrWithin("10.0.0.0/16"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Null: true},
+			},
+		},
+		{
+			name:      "unknown",
+			validator: CidrWithin("10.0.0.0/16"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.List{ElemType: types.StringType, Unknown: true},
+			},
+		},
+		{
+			name:      "wrong type",
+			validator: CidrWithin("10.0.0.0/16"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.Bool{Value: true},
+			},
+			err: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
@@ -5,15 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frankgreco/terraform-helpers/internal/parse"
 
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 const (
 	compareErr         = "The comparison failed."
-	compareDescription = "Ensures that the specificed attributes at the same level are not set (either null or unknown)."
+	compareDescription = "Ensures that the comparison between this attribute and another attribute holds."
 )
 
 type Comparator int
@@ -28,14 +32,58 @@ const (
 	ComparatorNot
 )
 
+// Format describes how the operands of a comparison are parsed before they are
+// compared. Every format other than FormatNone requires both operands to be strings.
+type Format int
+
+const (
+	// FormatNone compares the operands as they are.
+	FormatNone Format = iota
+	// FormatTimestamp parses the operands as RFC 3339 timestamps.
+	FormatTimestamp
+	// FormatDuration parses the operands as Go durations, e.g. "1h30m",
+	// or ISO 8601 durations, e.g. "PT1H30M".
+	FormatDuration
+	// FormatSemVer parses the operands as semantic versions, e.g. "v1.2.3-rc.1".
+	FormatSemVer
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatNone:
+		return "values"
+	case FormatTimestamp:
+		return "timestamps"
+	case FormatDuration:
+		return "durations"
+	case FormatSemVer:
+		return "semantic versions"
+	}
+	return "unknown"
+}
+
 type compareValidator struct {
+	format     Format
 	comparator Comparator
 	attribute  string
 }
 
-// Compare
+// Compare ensures that the comparison between this attribute and another attribute
+// holds, where this attribute is the left operand. The other attribute is a path
+// expression, e.g. "max" at the same level, "../node_count" one level up or
+// "/limits/max" from the root. Both attributes must be of the same type. Numbers
+// are compared exactly, strings lexicographically and bools only support
+// ComparatorEqual and ComparatorNot.
 func Compare(comparator Comparator, attribute string) tfsdk.AttributeValidator {
+	return CompareAs(FormatNone, comparator, attribute)
+}
+
+// CompareAs is the same as Compare, except that both attributes are strings that
+// are parsed according to the format before they are compared, e.g. FormatDuration
+// compares "90m" and "PT1H" as durations.
+func CompareAs(format Format, comparator Comparator, attribute string) tfsdk.AttributeValidator {
 	return compareValidator{
+		format:     format,
 		comparator: comparator,
 		attribute:  attribute,
 	}
@@ -61,7 +109,7 @@ func (v compareValidator) Validate(ctx context.Context, req tfsdk.ValidateAttrib
 		return
 	}
 
-	this, err := toValue(ctx, req.AttributeConfig)
+	this, err := attributeValue(ctx, req)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			compareErr,
@@ -75,40 +123,102 @@ func (v compareValidator) Validate(ctx context.Context, req tfsdk.ValidateAttrib
 		return
 	}
 
-	var parent types.Object
-	{
-		// I think this is almost always guaranteed to be an Object?
-		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.AttributePath.WithoutLastStep(), &parent)...)
-		if resp.Diagnostics.HasError() || parent.Null || parent.Unknown {
-			return
-		}
+	data, _, ok, diags := getAttribute(ctx, req, v.attribute)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() || !ok {
+		return
 	}
 
-	// I believe the only way this wouldn't be true
-	// is if they pass in an unknown attribute.
-	attrValue, ok := parent.Attrs[v.attribute]
-	if !ok {
+	// Check if the attribute is "actually" set.
+	if !data.IsFullyKnown() || data.IsNull() {
 		return
 	}
 
-	data, err := toValue(ctx, attrValue)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			compareErr,
-			"The validator had an internal error: "+err.Error(),
-		)
+	if err := compareAs(this, data, v.format, v.comparator); err != nil {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, compareErr, err.Error())
 		return
 	}
+}
 
-	// Check if the attribute is "actually" set.
-	if !data.IsFullyKnown() || data.IsNull() {
-		return
+func compareAs(left, right tftypes.Value, format Format, comparator Comparator) error {
+	if format == FormatNone {
+		return compare(left, right, comparator)
 	}
 
-	if err := compare(this, data, v.comparator); err != nil {
-		resp.Diagnostics.AddError(compareErr, err.Error())
-		return
+	if !left.Type().Is(tftypes.String) || !right.Type().Is(tftypes.String) {
+		return fmt.Errorf("Unsupported type. Only strings can be compared as %s.", format)
+	}
+
+	var leftOp, rightOp string
+	if err := left.As(&leftOp); err != nil {
+		return errors.New("The validator had an internal error: " + err.Error())
+	}
+	if err := right.As(&rightOp); err != nil {
+		return errors.New("The validator had an internal error: " + err.Error())
+	}
+
+	switch format {
+	case FormatTimestamp:
+		l, err := parse.Timestamp(leftOp)
+		if err != nil {
+			return err
+		}
+		r, err := parse.Timestamp(rightOp)
+		if err != nil {
+			return err
+		}
+		c := 0
+		if l.Before(r) {
+			c = -1
+		} else if l.After(r) {
+			c = 1
+		}
+		return compareResult(
+			c, comparator,
+			formatParsed(leftOp, l.UTC().Format(time.RFC3339Nano)),
+			formatParsed(rightOp, r.UTC().Format(time.RFC3339Nano)),
+		)
+	case FormatDuration:
+		l, err := parse.Duration(leftOp)
+		if err != nil {
+			return err
+		}
+		r, err := parse.Duration(rightOp)
+		if err != nil {
+			return err
+		}
+		c := 0
+		if l < r {
+			c = -1
+		} else if l > r {
+			c = 1
+		}
+		return compareResult(
+			c, comparator,
+			formatParsed(leftOp, l.String()),
+			formatParsed(rightOp, r.String()),
+		)
+	case FormatSemVer:
+		l, err := parse.SemVer(leftOp)
+		if err != nil {
+			return err
+		}
+		r, err := parse.SemVer(rightOp)
+		if err != nil {
+			return err
+		}
+		return compareResult(
+			l.Compare(r), comparator,
+			formatParsed(leftOp, l.String()),
+			formatParsed(rightOp, r.String()),
+		)
 	}
+	return errors.New("Unknown format")
+}
+
+// formatParsed describes an operand by both its original and parsed forms.
+func formatParsed(original, parsed string) string {
+	return fmt.Sprintf("%s (%s)", strconv.Quote(original), parsed)
 }
 
 func compare(left, right tftypes.Value, comparator Comparator) error {
@@ -116,55 +226,97 @@ func compare(left, right tftypes.Value, comparator Comparator) error {
 		return errors.New("The type of both operands must match")
 	}
 
-	// TOOD: Add other types as needed.
-	if left.Type().Is(tftypes.Number) {
-		var leftOp, rightOp *big.Float
+	switch {
+	case left.Type().Is(tftypes.Number):
+		// A zero precision target takes on the precision of the value,
+		// whereas a nil *big.Float would be allocated with float64 precision.
+		leftOp, rightOp := new(big.Float), new(big.Float)
+		if err := left.As(leftOp); err != nil {
+			return errors.New("The validator had an internal error: " + err.Error())
+		}
+		if err := right.As(rightOp); err != nil {
+			return errors.New("The validator had an internal error: " + err.Error())
+		}
+		return compareNumber(leftOp, rightOp, comparator)
+	case left.Type().Is(tftypes.String):
+		var leftOp, rightOp string
 		if err := left.As(&leftOp); err != nil {
 			return errors.New("The validator had an internal error: " + err.Error())
 		}
 		if err := right.As(&rightOp); err != nil {
 			return errors.New("The validator had an internal error: " + err.Error())
 		}
-		return compareNumber(leftOp, rightOp, comparator)
+		return compareString(leftOp, rightOp, comparator)
+	case left.Type().Is(tftypes.Bool):
+		var leftOp, rightOp bool
+		if err := left.As(&leftOp); err != nil {
+			return errors.New("The validator had an internal error: " + err.Error())
+		}
+		if err := right.As(&rightOp); err != nil {
+			return errors.New("The validator had an internal error: " + err.Error())
+		}
+		return compareBool(leftOp, rightOp, comparator)
 	}
-	return errors.New("Unsupported type. Only string and number are supported.")
+	return errors.New("Unsupported type. Only string, number and bool are supported.")
 }
 
-func compareNumber(l, r *big.Float, comparator Comparator) error {
-	left, _ := l.Float64()
-	right, _ := r.Float64()
+func compareNumber(left, right *big.Float, comparator Comparator) error {
+	return compareResult(left.Cmp(right), comparator, formatNumber(left), formatNumber(right))
+}
+
+// compareString compares the strings lexicographically, byte-wise.
+func compareString(left, right string, comparator Comparator) error {
+	return compareResult(strings.Compare(left, right), comparator, strconv.Quote(left), strconv.Quote(right))
+}
+
+// compareBool compares the bools, which only have a notion of equality.
+func compareBool(left, right bool, comparator Comparator) error {
+	if comparator != ComparatorEqual && comparator != ComparatorNot {
+		return errors.New("Only the equal and not comparators are supported for bools.")
+	}
+
+	c := 0
+	if left != right {
+		c = 1
+	}
+	return compareResult(c, comparator, strconv.FormatBool(left), strconv.FormatBool(right))
+}
 
+// compareResult checks that the comparator holds for c, the result of comparing
+// the left operand to the right one (-1, 0 or +1). The operands are only used
+// to describe the failure, so they should be formatted as the user wrote them.
+func compareResult(c int, comparator Comparator, left, right string) error {
 	// Using the following pattern for readability.
 	switch comparator {
 	case ComparatorLessThan:
-		if left < right {
+		if c < 0 {
 		} else {
-			return fmt.Errorf("%f is not less than %f", left, right)
+			return fmt.Errorf("%s is not less than %s", left, right)
 		}
 	case ComparatorGreaterThan:
-		if left > right {
+		if c > 0 {
 		} else {
-			return fmt.Errorf("%f is not greater than %f", left, right)
+			return fmt.Errorf("%s is not greater than %s", left, right)
 		}
 	case ComparatorEqual:
-		if left == right {
+		if c == 0 {
 		} else {
-			return fmt.Errorf("%f is not equal to %f", left, right)
+			return fmt.Errorf("%s is not equal to %s", left, right)
 		}
 	case ComparatorLessThanEqual:
-		if left <= right {
+		if c <= 0 {
 		} else {
-			return fmt.Errorf("%f is not less than or equal to %f", left, right)
+			return fmt.Errorf("%s is not less than or equal to %s", left, right)
 		}
 	case ComparatorGreaterThanEqual:
-		if left >= right {
+		if c >= 0 {
 		} else {
-			return fmt.Errorf("%f is not greater than or equal to %f", left, right)
+			return fmt.Errorf("%s is not greater than or equal to %s", left, right)
 		}
 	case ComparatorNot:
-		if left != right {
+		if c != 0 {
 		} else {
-			return fmt.Errorf("%f is not (not equal) to %f", left, right)
+			return fmt.Errorf("%s is not (not equal) to %s", left, right)
 		}
 	default:
 		return errors.New("Unknown comparator")
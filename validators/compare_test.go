@@ -0,0 +1,320 @@
+package validators
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCompare(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("min")
+
+	// 2^53 + 1 can't be represented by a float64.
+	large, _, _ := big.ParseFloat("9007199254740993", 10, 512, big.ToNearestEven)
+	larger, _, _ := big.ParseFloat("9007199254740992", 10, 512, big.ToNearestEven)
+
+	for _, test := range []testCase{
+		{
+			name:      "numbers pass",
+			validator: Compare(ComparatorLessThanEqual, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.Number{Value: big.NewFloat(1.5)},
+				"max": types.Number{Value: big.NewFloat(1.5)},
+			}),
+		},
+		{
+			name:      "numbers fail",
+			validator: Compare(ComparatorLessThan, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.Number{Value: big.NewFloat(2.25)},
+				"max": types.Number{Value: big.NewFloat(2)},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, compareErr, "2.25 is not less than 2"),
+			},
+		},
+		{
+			name:      "numbers are compared exactly",
+			validator: Compare(ComparatorLessThanEqual, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.Number{Value: large},
+				"max": types.Number{Value: larger},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, compareErr, "9007199254740993 is not less than or equal to 9007199254740992"),
+			},
+		},
+		{
+			name:      "numbers read by the framework are compared exactly",
+			validator: Compare(ComparatorEqual, "max"),
+			request: testFrameworkRequest("min", map[string]attr.Value{
+				"min": types.Number{Value: large},
+				"max": types.Number{Value: large},
+			}),
+		},
+		{
+			name:      "numbers read by the framework fail exactly",
+			validator: Compare(ComparatorLessThan, "max"),
+			request: testFrameworkRequest("min", map[string]attr.Value{
+				"min": types.Number{Value: large},
+				"max": types.Number{Value: larger},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, compareErr, "9007199254740993 is not less than 9007199254740992"),
+			},
+		},
+		{
+			name:      "strings pass",
+			validator: Compare(ComparatorLessThan, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.String{Value: "apple"},
+				"max": types.String{Value: "banana"},
+			}),
+		},
+		{
+			name:      "strings fail",
+			validator: Compare(ComparatorEqual, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.String{Value: "apple"},
+				"max": types.String{Value: "banana"},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, compareErr, `"apple" is not equal to "banana"`),
+			},
+		},
+		{
+			name:      "bools pass",
+			validator: Compare(ComparatorNot, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.Bool{Value: true},
+				"max": types.Bool{Value: false},
+			}),
+		},
+		{
+			name:      "bools fail",
+			validator: Compare(ComparatorEqual, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.Bool{Value: true},
+				"max": types.Bool{Value: false},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, compareErr, "true is not equal to false"),
+			},
+		},
+		{
+			name:      "bools are not ordered",
+			validator: Compare(ComparatorLessThan, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.Bool{Value: false},
+				"max": types.Bool{Value: true},
+			}),
+			err: true,
+		},
+		{
+			name:      "mismatched types",
+			validator: Compare(ComparatorEqual, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.String{Value: "1"},
+				"max": types.Number{Value: big.NewFloat(1)},
+			}),
+			err: true,
+		},
+		{
+			name:      "missing attribute",
+			validator: Compare(ComparatorLessThan, "maximum"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.Number{Value: big.NewFloat(1)},
+				"max": types.Number{Value: big.NewFloat(2)},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, invalidPathErr, `The path "maximum" (maximum) does not exist in the schema.`),
+			},
+		},
+		{
+			name:      "unknown",
+			validator: Compare(ComparatorLessThan, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.Number{Value: big.NewFloat(2)},
+				"max": types.Number{Unknown: true},
+			}),
+		},
+		{
+			name:      "null",
+			validator: Compare(ComparatorLessThan, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.Number{Null: true},
+				"max": types.Number{Value: big.NewFloat(2)},
+			}),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
+
+func TestCompareAs(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("min")
+
+	for _, test := range []testCase{
+		{
+			name:      "timestamps pass",
+			validator: CompareAs(FormatTimestamp, ComparatorLessThan, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.String{Value: "2022-01-01T12:30:00+01:00"},
+				"max": types.String{Value: "2022-01-01T12:00:00Z"},
+			}),
+		},
+		{
+			name:      "timestamps fail",
+			validator: CompareAs(FormatTimestamp, ComparatorLessThan, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.String{Value: "2022-01-01T13:00:00+01:00"},
+				"max": types.String{Value: "2022-01-01T12:00:00Z"},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					compareErr,
+					`"2022-01-01T13:00:00+01:00" (2022-01-01T12:00:00Z) is not less than "2022-01-01T12:00:00Z" (2022-01-01T12:00:00Z)`,
+				),
+			},
+		},
+		{
+			name:      "durations pass",
+			validator: CompareAs(FormatDuration, ComparatorLessThanEqual, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.String{Value: "30s"},
+				"max": types.String{Value: "PT1M"},
+			}),
+		},
+		{
+			name:      "durations fail",
+			validator: CompareAs(FormatDuration, ComparatorLessThanEqual, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.String{Value: "90m"},
+				"max": types.String{Value: "PT1H"},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, compareErr, `"90m" (1h30m0s) is not less than or equal to "PT1H" (1h0m0s)`),
+			},
+		},
+		{
+			name:      "invalid duration",
+			validator: CompareAs(FormatDuration, ComparatorLessThanEqual, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.String{Value: "P1M"},
+				"max": types.String{Value: "PT1H"},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, compareErr, `Invalid duration "P1M": years and months do not have a fixed length`),
+			},
+		},
+		{
+			name:      "versions pass",
+			validator: CompareAs(FormatSemVer, ComparatorLessThan, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.String{Value: "v1.10.0-rc.1"},
+				"max": types.String{Value: "1.10.0"},
+			}),
+		},
+		{
+			name:      "versions fail",
+			validator: CompareAs(FormatSemVer, ComparatorLessThan, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.String{Value: "v1.10.0"},
+				"max": types.String{Value: "1.9.0"},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, compareErr, `"v1.10.0" (1.10.0) is not less than "1.9.0" (1.9.0)`),
+			},
+		},
+		{
+			name:      "not strings",
+			validator: CompareAs(FormatSemVer, ComparatorLessThan, "max"),
+			request: testRequest("min", map[string]attr.Value{
+				"min": types.Number{Value: big.NewFloat(1)},
+				"max": types.Number{Value: big.NewFloat(2)},
+			}),
+			err: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
+
+func TestComparePaths(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("autoscaling").WithAttributeName("max")
+	config := testConfig(map[string]attr.Value{
+		"node_count": types.Number{Value: big.NewFloat(3)},
+		"autoscaling": types.Object{
+			AttrTypes: map[string]attr.Type{
+				"min": types.NumberType,
+				"max": types.NumberType,
+			},
+			Attrs: map[string]attr.Value{
+				"min": types.Number{Value: big.NewFloat(1)},
+				"max": types.Number{Value: big.NewFloat(5)},
+			},
+		},
+	})
+	request := tfsdk.ValidateAttributeRequest{
+		AttributePath:   path,
+		AttributeConfig: types.Number{Value: big.NewFloat(5)},
+		Config:          config,
+	}
+
+	for _, test := range []testCase{
+		{
+			name:      "sibling",
+			validator: Compare(ComparatorGreaterThan, "min"),
+			request:   request,
+		},
+		{
+			name:      "parent",
+			validator: Compare(ComparatorGreaterThanEqual, "../node_count"),
+			request:   request,
+		},
+		{
+			name:      "root",
+			validator: Compare(ComparatorLessThanEqual, "/node_count"),
+			request:   request,
+			err:       true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, compareErr, "5 is not less than or equal to 3"),
+			},
+		},
+		{
+			name:      "relative",
+			validator: Compare(ComparatorEqual, "../autoscaling/max"),
+			request:   request,
+		},
+		{
+			name:      "invalid",
+			validator: Compare(ComparatorEqual, "../../node_count"),
+			request:   request,
+			err:       true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
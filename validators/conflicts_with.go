@@ -5,19 +5,20 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
 	conflictsWithErr         = "There was a conflict detected."
-	conflictsWithDescription = "Ensures that the specificed attributes at the same level are not set (either null or unknown)."
+	conflictsWithDescription = "Ensures that the specificed attributes are not set (either null or unknown)."
 )
 
 type conflictsWithValidator struct {
 	conflicts []string
 }
 
-// ConflictsWith ensures that the specificed attributes at the same level are not set (either null or unknown).
+// ConflictsWith ensures that the specificed attributes are not set (either null or unknown).
+// The attributes are path expressions, e.g. "foo" at the same level, "../foo" one level up
+// or "/foo" from the root.
 func ConflictsWith(attributes ...string) tfsdk.AttributeValidator {
 	return conflictsWithValidator{
 		conflicts: attributes,
@@ -54,35 +55,16 @@ func (v conflictsWithValidator) Validate(ctx context.Context, req tfsdk.Validate
 		return
 	}
 
-	var parent types.Object
-	{
-		// I think this is almost always guaranteed to be an Object?
-		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.AttributePath.WithoutLastStep(), &parent)...)
-		if resp.Diagnostics.HasError() || parent.Null || parent.Unknown {
-			return
-		}
-	}
-
 	conflicts := []string{}
 	for _, conflict := range v.conflicts {
-		// I believe the only way this wouldn't be true
-		// is if they pass in an unknown attribute.
-		attrValue, ok := parent.Attrs[conflict]
-		if !ok {
-			continue
-		}
-
-		data, err := toValue(ctx, attrValue)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				conflictsWithErr,
-				"The validator had an internal error: "+err.Error(),
-			)
+		data, _, ok, diags := getAttribute(ctx, req, conflict)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
 			return
 		}
 
 		// Check if the attribute is "actually" set.
-		if data.IsFullyKnown() && !data.IsNull() {
+		if ok && data.IsFullyKnown() && !data.IsNull() {
 			conflicts = append(conflicts, conflict)
 		}
 	}
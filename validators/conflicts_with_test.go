@@ -0,0 +1,91 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestConflictsWith(t *testing.T) {
+	nested := tfsdk.ValidateAttributeRequest{
+		AttributePath:   tftypes.NewAttributePath().WithAttributeName("tls").WithAttributeName("cert"),
+		AttributeConfig: types.String{Value: "cert"},
+		Config: testConfig(map[string]attr.Value{
+			"insecure": types.Bool{Value: true},
+			"tls": types.Object{
+				AttrTypes: map[string]attr.Type{"cert": types.StringType, "key": types.StringType},
+				Attrs: map[string]attr.Value{
+					"cert": types.String{Value: "cert"},
+					"key":  types.String{Null: true},
+				},
+			},
+		}),
+	}
+
+	for _, test := range []testCase{
+		{
+			name:      "pass",
+			validator: ConflictsWith("password"),
+			request: testRequest("ssh_key", map[string]attr.Value{
+				"ssh_key":  types.String{Value: "key"},
+				"password": types.String{Null: true},
+			}),
+		},
+		{
+			name:      "fail",
+			validator: ConflictsWith("password", "certificate"),
+			request: testRequest("ssh_key", map[string]attr.Value{
+				"ssh_key":     types.String{Value: "key"},
+				"password":    types.String{Value: "hunter2"},
+				"certificate": types.String{Unknown: true},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewErrorDiagnostic(conflictsWithErr, `AttributeName("ssh_key") conficts with password.`),
+			},
+		},
+		{
+			name:      "parent not set",
+			validator: ConflictsWith("tls/key"),
+			request: testRequest("insecure", map[string]attr.Value{
+				"insecure": types.Bool{Value: true},
+				"tls": types.Object{
+					AttrTypes: map[string]attr.Type{"key": types.StringType},
+					Null:      true,
+				},
+			}),
+		},
+		{
+			name:      "nested sibling",
+			validator: ConflictsWith("key"),
+			request:   nested,
+		},
+		{
+			name:      "parent",
+			validator: ConflictsWith("../insecure"),
+			request:   nested,
+			err:       true,
+		},
+		{
+			name:      "missing attribute",
+			validator: ConflictsWith("insecure"),
+			request:   nested,
+			err:       true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					nested.AttributePath,
+					invalidPathErr,
+					`The path "insecure" (tls.insecure) does not exist in the schema.`,
+				),
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
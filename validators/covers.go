@@ -0,0 +1,197 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frankgreco/terraform-helpers/internal/overlap"
+	"github.com/frankgreco/terraform-helpers/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	coversErr         = "Incomplete coverage detected."
+	coversDescription = "Ensures that the elements of the list together cover the whole span without any gaps."
+)
+
+type coversValidator struct {
+	span    utils.OrderedPair
+	encoded string
+	err     error
+}
+
+// CoversRange ensures that the ranges of the {from, to} objects in the list
+// or set together supply every whole number between from and to (inclusive),
+// and nothing outside of it. Use NoOverlap to also forbid overlapping ranges.
+func CoversRange(from, to int64) tfsdk.AttributeValidator {
+	v := coversValidator{
+		span:    utils.NewOrderedPair(utils.NewIntKey(from), utils.NewIntKey(to)),
+		encoded: fmt.Sprintf("%d-%d", from, to),
+	}
+	if from > to {
+		v.err = fmt.Errorf("This validator was initialized with an invalid range: %d is greater than %d", from, to)
+	}
+	return v
+}
+
+// CoversCIDR ensures that the CIDRs, or {from, to} objects bounded by IP addresses,
+// in the list or set together supply every address in cidr, and nothing outside of
+// it. Use NoOverlappingCIDRs or NoOverlap to also forbid overlapping ranges.
+func CoversCIDR(cidr string) tfsdk.AttributeValidator {
+	span, err := overlap.ParseCIDR(cidr)
+	if err != nil {
+		err = errors.New("This validator was initialized with an invalid CIDR: " + err.Error())
+	}
+	return coversValidator{
+		span:    span,
+		encoded: cidr,
+		err:     err,
+	}
+}
+
+// Description describes this validator.
+func (v coversValidator) Description(context.Context) string {
+	return coversDescription
+}
+
+// MarkdownDescription describes this validator.
+func (v coversValidator) MarkdownDescription(context.Context) string {
+	return coversDescription
+}
+
+// Validate performs validation on an attribute.
+func (v coversValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	if v.err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			coversErr,
+			v.err.Error(),
+		)
+		return
+	}
+
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			coversErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	// The gaps can't be known until every element is known.
+	if !this.IsFullyKnown() || this.IsNull() {
+		return
+	}
+
+	isList := this.Type().Is(tftypes.List{})
+	if !isList && !this.Type().Is(tftypes.Set{}) {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			coversErr,
+			"Unsupported type. Only lists and sets are supported.",
+		)
+		return
+	}
+
+	var elems []tftypes.Value
+	if err := this.As(&elems); err != nil {
+		resp.Diagnostics.AddError(
+			coversErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	var pairs []utils.OrderedPair
+	for i, elem := range elems {
+		path := req.AttributePath.WithElementKeyValue(elem)
+		if isList {
+			path = req.AttributePath.WithElementKeyInt(i)
+		}
+
+		if elem.IsNull() {
+			continue
+		}
+
+		pair, err := coverPair(elem)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path, coversErr, err.Error())
+			continue
+		}
+
+		if !utils.SameKind(pair.First(), v.span.First()) || !utils.Contains(v.span, pair) {
+			resp.Diagnostics.AddAttributeError(
+				path,
+				coversErr,
+				fmt.Sprintf("%s (%s) is not within %s.", formatPath(path), formatValue(elem), v.encoded),
+			)
+			continue
+		}
+
+		pairs = append(pairs, pair)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gaps, err := utils.Gaps(v.span, pairs)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			coversErr,
+			err.Error(),
+		)
+		return
+	}
+
+	for _, gap := range gaps {
+		detail := fmt.Sprintf("The elements between %s and %s are not supplied by any range.", gap.First(), gap.Last())
+		if gap.First().Compare(gap.Last()) == 0 {
+			detail = fmt.Sprintf("The element %s is not supplied by any range.", gap.First())
+		}
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			coversErr,
+			detail,
+		)
+	}
+}
+
+// coverPair returns the range supplied by the element, which must be either
+// a CIDR or a {from, to} object.
+func coverPair(elem tftypes.Value) (utils.OrderedPair, error) {
+	if elem.Type().Is(tftypes.String) {
+		var cidr string
+		if err := elem.As(&cidr); err != nil {
+			return nil, errors.New("The validator had an internal error: " + err.Error())
+		}
+		return overlap.ParseCIDR(cidr)
+	}
+
+	var attrs map[string]tftypes.Value
+	if !elem.Type().Is(tftypes.Object{}) || elem.As(&attrs) != nil {
+		return nil, errors.New("Unsupported element type. Only strings and objects are supported.")
+	}
+
+	from, fromOk, err := boundKey(attrs, "from")
+	if err != nil {
+		return nil, err
+	}
+	to, toOk, err := boundKey(attrs, "to")
+	if err != nil {
+		return nil, err
+	}
+	if !fromOk || !toOk {
+		return nil, errors.New("Both from and to must be set.")
+	}
+	if !utils.SameKind(from, to) || from.Compare(to) > 0 {
+		return nil, fmt.Errorf("from (%s) must be less than or equal to to (%s).", from, to)
+	}
+
+	return utils.NewOrderedPair(from, to), nil
+}
@@ -0,0 +1,163 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCovers(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("shards")
+
+	for _, test := range []testCase{
+		{
+			name:      "range pass",
+			validator: CoversRange(0, 1023),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: fromToList([2]int64{512, 1023}, [2]int64{0, 511}),
+			},
+		},
+		{
+			name:      "range gaps",
+			validator: CoversRange(0, 1023),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: fromToList([2]int64{1, 511}, [2]int64{600, 1022}),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					coversErr,
+					"The element 0 is not supplied by any range.",
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					coversErr,
+					"The elements between 512 and 599 are not supplied by any range.",
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					coversErr,
+					"The element 1023 is not supplied by any range.",
+				),
+			},
+		},
+		{
+			name:      "range outside of span",
+			validator: CoversRange(0, 1023),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: fromToList([2]int64{0, 1023}, [2]int64{1000, 1024}),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(1),
+					coversErr,
+					"shards[1] ({from = 1000, to = 1024}) is not within 0-1023.",
+				),
+			},
+		},
+		{
+			name:      "invalid range",
+			validator: CoversRange(10, 0),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: fromToList([2]int64{0, 10}),
+			},
+			err: true,
+		},
+		{
+			name:      "cidrs pass",
+			validator: CoversCIDR("10.0.0.0/24"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("10.0.0.128/25", "10.0.0.0/26", "10.0.0.64/26"),
+			},
+		},
+		{
+			name:      "cidrs gap",
+			validator: CoversCIDR("10.0.0.0/24"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("10.0.0.128/25", "10.0.0.0/26"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					coversErr,
+					"The elements between 10.0.0.64 and 10.0.0.127 are not supplied by any range.",
+				),
+			},
+		},
+		{
+			name:      "cidr of another family",
+			validator: CoversCIDR("10.0.0.0/24"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("10.0.0.0/24", "2001:db8::/64"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(1),
+					coversErr,
+					`shards[1] ("2001:db8::/64") is not within 10.0.0.0/24.`,
+				),
+			},
+		},
+		{
+			name:      "ip bounds pass",
+			validator: CoversCIDR("10.0.0.0/24"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"from": types.String{Value: "10.0.0.0"}, "to": types.String{Value: "10.0.0.99"}},
+					map[string]attr.Value{"from": types.String{Value: "10.0.0.100"}, "to": types.String{Value: "10.0.0.255"}},
+				),
+			},
+		},
+		{
+			name:      "invalid cidr",
+			validator: CoversCIDR("10.0.0.0/33"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("10.0.0.0/24"),
+			},
+			err: true,
+		},
+		{
+			name:      "unknown element",
+			validator: CoversCIDR("10.0.0.0/24"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.StringType,
+					Elems:    []attr.Value{types.String{Value: "10.0.0.0/25"}, types.String{Unknown: true}},
+				},
+			},
+		},
+		{
+			name:      "null",
+			validator: CoversRange(0, 1023),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: fromToType,
+					Null:     true,
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
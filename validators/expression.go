@@ -0,0 +1,154 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/frankgreco/terraform-helpers/internal/expr"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	expressionErr         = "The expression failed."
+	expressionDescription = "Ensures that the expression %q holds."
+)
+
+type expressionValidator struct {
+	src  string
+	expr *expr.Expression
+	err  error
+}
+
+// Expression ensures that the expression holds, e.g. `self <= max_size && self >= min_size * 2`
+// or `len(zones) >= replicas`. self is the attribute being validated and every other identifier
+// is an attribute at the same level. The expression supports number, string and bool literals,
+// attribute access (autoscaling.max), arithmetic (+ - * / %), comparisons (== != < <= > >=),
+// logical operators (&& || !), len() and parentheses.
+//
+// The expression is parsed once, here, and is not checked while any attribute it depends on
+// is unknown or null.
+func Expression(src string) tfsdk.AttributeValidator {
+	e, err := expr.Parse(src)
+	return expressionValidator{
+		src:  src,
+		expr: e,
+		err:  err,
+	}
+}
+
+// Description describes this validator.
+func (v expressionValidator) Description(context.Context) string {
+	return fmt.Sprintf(expressionDescription, v.src)
+}
+
+// MarkdownDescription describes this validator.
+func (v expressionValidator) MarkdownDescription(context.Context) string {
+	return fmt.Sprintf("Ensures that the expression `%s` holds.", v.src)
+}
+
+// Validate performs validation on an attribute.
+func (v expressionValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	if v.err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Expression",
+			"This validator was initialized with an invalid expression: "+v.err.Error(),
+		)
+		return
+	}
+
+	this, err := attributeValue(ctx, req)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			expressionErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	env := map[string]expr.Value{}
+	for _, name := range v.expr.Identifiers() {
+		if name == "self" {
+			env[name] = exprValue(this)
+			continue
+		}
+
+		value, _, ok, diags := getAttribute(ctx, req, name)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+		env[name] = expr.Unknown
+		if ok {
+			env[name] = exprValue(value)
+		}
+	}
+
+	ok, known, reason, err := v.expr.Check(env)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Expression",
+			err.Error(),
+		)
+		return
+	}
+
+	// The expression can't be checked until the attributes it depends on are "set".
+	if !known || ok {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.AttributePath,
+		expressionErr,
+		fmt.Sprintf("%s does not satisfy %q because %s is false.", formatPath(req.AttributePath), v.src, reason),
+	)
+}
+
+// exprValue converts the value for use in an expression. Null values
+// are treated as unknown so that the expression is not checked.
+func exprValue(value tftypes.Value) expr.Value {
+	if !value.IsKnown() || value.IsNull() {
+		return expr.Unknown
+	}
+
+	switch typ := value.Type(); {
+	case typ.Is(tftypes.String):
+		var str string
+		_ = value.As(&str)
+		return str
+	case typ.Is(tftypes.Number):
+		var number big.Float
+		_ = value.As(&number)
+		if number.IsInf() {
+			return expr.Unknown
+		}
+		r, _ := number.Rat(nil)
+		return r
+	case typ.Is(tftypes.Bool):
+		var b bool
+		_ = value.As(&b)
+		return b
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		_ = value.As(&elems)
+		values := make([]expr.Value, len(elems))
+		for i, elem := range elems {
+			values[i] = exprValue(elem)
+		}
+		return values
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var attrs map[string]tftypes.Value
+		_ = value.As(&attrs)
+		values := make(map[string]expr.Value, len(attrs))
+		for name, attr := range attrs {
+			values[name] = exprValue(attr)
+		}
+		return values
+	}
+	return expr.Unknown
+}
@@ -0,0 +1,110 @@
+package validators
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestExpression(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("size")
+
+	sizes := func(size, min, max types.Number) map[string]attr.Value {
+		return map[string]attr.Value{
+			"size":     size,
+			"min_size": min,
+			"max_size": max,
+		}
+	}
+	n := func(f float64) types.Number {
+		return types.Number{Value: big.NewFloat(f)}
+	}
+
+	// 2^53 + 1 can't be represented by a float64.
+	large, _, _ := big.ParseFloat("9007199254740993", 10, 512, big.ToNearestEven)
+
+	for _, test := range []testCase{
+		{
+			name:      "pass",
+			validator: Expression("self <= max_size && self >= min_size * 2"),
+			request:   testRequest("size", sizes(n(8), n(3), n(10))),
+		},
+		{
+			name:      "fail",
+			validator: Expression("self <= max_size && self >= min_size * 2"),
+			request:   testRequest("size", sizes(n(5), n(3), n(10))),
+			err:       true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					expressionErr,
+					`size does not satisfy "self <= max_size && self >= min_size * 2" because self >= min_size * 2 (5 >= 6) is false.`,
+				),
+			},
+		},
+		{
+			name:      "len",
+			validator: Expression("len(zones) >= replicas"),
+			request: testRequest("replicas", map[string]attr.Value{
+				"replicas": n(3),
+				"zones":    stringList("a", "b"),
+			}),
+			err: true,
+		},
+		{
+			name:      "len of partially unknown list",
+			validator: Expression("len(zones) >= replicas"),
+			request: testRequest("replicas", map[string]attr.Value{
+				"replicas": n(2),
+				"zones": types.List{
+					ElemType: types.StringType,
+					Elems:    []attr.Value{types.String{Value: "a"}, types.String{Unknown: true}},
+				},
+			}),
+		},
+		{
+			name:      "unknown",
+			validator: Expression("self <= max_size"),
+			request:   testRequest("size", sizes(n(50), n(3), types.Number{Unknown: true})),
+		},
+		{
+			name:      "null",
+			validator: Expression("self <= max_size"),
+			request:   testRequest("size", sizes(types.Number{Null: true}, n(3), n(10))),
+		},
+		{
+			name:      "type error",
+			validator: Expression("self <= name"),
+			request: testRequest("size", map[string]attr.Value{
+				"size": n(1),
+				"name": types.String{Value: "a"},
+			}),
+			err: true,
+		},
+		{
+			name:      "missing attribute",
+			validator: Expression("self <= maximum"),
+			request:   testRequest("size", sizes(n(1), n(3), n(10))),
+			err:       true,
+		},
+		{
+			name:      "numbers read by the framework are compared exactly",
+			validator: Expression("self == max_size"),
+			request:   testFrameworkRequest("size", sizes(types.Number{Value: large}, n(3), types.Number{Value: large})),
+		},
+		{
+			name:      "invalid expression",
+			validator: Expression("self <="),
+			request:   testRequest("size", sizes(n(1), n(3), n(10))),
+			err:       true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
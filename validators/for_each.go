@@ -0,0 +1,148 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	forEachErr = "The element validation failed."
+)
+
+type forEachValidator struct {
+	validators []tfsdk.AttributeValidator
+}
+
+// ForEach runs the validators against every element of a list, set or map,
+// e.g. ForEach(Cidr(), NoWhitespace()) for a list(string) of CIDRs. Each element
+// is validated as if it was an attribute of its own, at the path of the element:
+// cidrs[2] for a list, cidrs["10.0.0.0/24"] for a set and tags["env"] for a map.
+// Nothing is validated while the collection itself is unknown.
+func ForEach(validators ...tfsdk.AttributeValidator) tfsdk.AttributeValidator {
+	return forEachValidator{
+		validators: validators,
+	}
+}
+
+// Description describes this validator.
+func (v forEachValidator) Description(ctx context.Context) string {
+	descriptions := make([]string, len(v.validators))
+	for i, validator := range v.validators {
+		descriptions[i] = validator.Description(ctx)
+	}
+	return fmt.Sprintf("For every element: %s", strings.Join(descriptions, " "))
+}
+
+// MarkdownDescription describes this validator.
+func (v forEachValidator) MarkdownDescription(ctx context.Context) string {
+	descriptions := make([]string, len(v.validators))
+	for i, validator := range v.validators {
+		descriptions[i] = validator.MarkdownDescription(ctx)
+	}
+	return fmt.Sprintf("For every element: %s", strings.Join(descriptions, " "))
+}
+
+// Validate performs validation on an attribute.
+func (v forEachValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			forEachErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	// We don't need to do any validation if the value isn't "set".
+	if !this.IsKnown() || this.IsNull() {
+		return
+	}
+
+	typ, ok := req.AttributeConfig.Type(ctx).(attr.TypeWithElementType)
+	if !ok {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			forEachErr,
+			"Unsupported type. Only lists, sets and maps are supported.",
+		)
+		return
+	}
+
+	elems, paths, err := elements(this, req.AttributePath)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			forEachErr,
+			err.Error(),
+		)
+		return
+	}
+
+	for i, elem := range elems {
+		value, err := typ.ElementType().ValueFromTerraform(ctx, elem)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				forEachErr,
+				"The validator had an internal error: "+err.Error(),
+			)
+			return
+		}
+
+		elemReq := tfsdk.ValidateAttributeRequest{
+			AttributePath:   paths[i],
+			AttributeConfig: value,
+			Config:          req.Config,
+		}
+		for _, validator := range v.validators {
+			validator.Validate(ctx, elemReq, resp)
+		}
+	}
+}
+
+// elements returns the elements of the list, set or map along with their
+// paths. The elements of a map are ordered by their keys.
+func elements(value tftypes.Value, path *tftypes.AttributePath) ([]tftypes.Value, []*tftypes.AttributePath, error) {
+	switch typ := value.Type(); {
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}):
+		var elems []tftypes.Value
+		if err := value.As(&elems); err != nil {
+			return nil, nil, fmt.Errorf("The validator had an internal error: %s", err)
+		}
+
+		paths := make([]*tftypes.AttributePath, len(elems))
+		for i, elem := range elems {
+			if typ.Is(tftypes.List{}) {
+				paths[i] = path.WithElementKeyInt(i)
+			} else {
+				paths[i] = path.WithElementKeyValue(elem)
+			}
+		}
+		return elems, paths, nil
+	case typ.Is(tftypes.Map{}):
+		var attrs map[string]tftypes.Value
+		if err := value.As(&attrs); err != nil {
+			return nil, nil, fmt.Errorf("The validator had an internal error: %s", err)
+		}
+
+		keys := make([]string, 0, len(attrs))
+		for key := range attrs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		elems := make([]tftypes.Value, len(keys))
+		paths := make([]*tftypes.AttributePath, len(keys))
+		for i, key := range keys {
+			elems[i] = attrs[key]
+			paths[i] = path.WithElementKeyString(key)
+		}
+		return elems, paths, nil
+	}
+	return nil, nil, fmt.Errorf("Unsupported type %s. Only lists, sets and maps are supported.", value.Type())
+}
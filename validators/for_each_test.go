@@ -0,0 +1,165 @@
+package validators
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestForEach(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("cidrs")
+
+	for _, test := range []testCase{
+		{
+			name:      "list pass",
+			validator: ForEach(Cidr(), NoWhitespace()),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("10.0.0.0/24", "10.0.1.0/24"),
+			},
+		},
+		{
+			name:      "list fail",
+			validator: ForEach(Cidr()),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("10.0.0.0/24", "10.0.1.0/24", "10.0.2.0"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					"Invalid String Content",
+					cidrErr,
+				),
+			},
+		},
+		{
+			name:      "set fail",
+			validator: ForEach(MaxLength(3)),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.Set{
+					ElemType: types.StringType,
+					Elems:    []attr.Value{types.String{Value: "abc"}, types.String{Value: "abcd"}},
+				},
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyValue(tftypes.NewValue(tftypes.String, "abcd")),
+					"Invalid String Length",
+					"String must be at most 3 characters long.",
+				),
+			},
+		},
+		{
+			name:      "map fail",
+			validator: ForEach(NoWhitespace()),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.Map{
+					ElemType: types.StringType,
+					Elems: map[string]attr.Value{
+						"env":   types.String{Value: "prod"},
+						"owner": types.String{Value: "team a"},
+						"team":  types.String{Value: "a b"},
+					},
+				},
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyString("owner"),
+					"Invalid String Content",
+					noWhitespaceValidatorErr,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyString("team"),
+					"Invalid String Content",
+					noWhitespaceValidatorErr,
+				),
+			},
+		},
+		{
+			name:      "nested",
+			validator: ForEach(ForEach(Range(0.0, 10.0))),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.ListType{ElemType: types.NumberType},
+					Elems:    []attr.Value{numberList(1, 2), numberList(3, 11)},
+				},
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(1).WithElementKeyInt(1),
+					"Invalid Value",
+					"value must be between 0 and 10",
+				),
+			},
+		},
+		{
+			name:      "unknown elements",
+			validator: ForEach(Cidr()),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.StringType,
+					Elems:    []attr.Value{types.String{Unknown: true}, types.String{Null: true}},
+				},
+			},
+		},
+		{
+			name:      "unknown",
+			validator: ForEach(Cidr()),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.StringType,
+					Unknown:  true,
+				},
+			},
+		},
+		{
+			name:      "not a collection",
+			validator: ForEach(Range(0.0, 10.0)),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: types.Number{Value: big.NewFloat(1)},
+			},
+			err: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
+
+func TestForEachSiblings(t *testing.T) {
+	values := map[string]attr.Value{
+		"vpc_cidr": types.String{Value: "10.0.0.0/16"},
+		"cidrs":    stringList("10.0.1.0/24", "10.1.0.0/24"),
+	}
+
+	test := testCase{
+		validator: ForEach(CidrWithinAttribute("vpc_cidr")),
+		request:   testRequest("cidrs", values),
+		err:       true,
+		diagnostics: diag.Diagnostics{
+			diag.NewAttributeErrorDiagnostic(
+				tftypes.NewAttributePath().WithAttributeName("cidrs").WithElementKeyInt(1),
+				"Invalid CIDR",
+				`"10.1.0.0/24" is not within any of [10.0.0.0/16].`,
+			),
+		},
+	}
+	test.run(t)
+}
@@ -0,0 +1,197 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	mapKeysErr = "The key validation failed."
+)
+
+type mapKeysValidator struct {
+	validators []tfsdk.AttributeValidator
+}
+
+// MapKeys runs the string validators against every key of a map, e.g.
+// MapKeys(MaxLength(128), NoWhitespace(), NoPrefix("aws:")). Each key is
+// validated as if it was a string attribute at the path of its element,
+// e.g. tags["env"], and the diagnostics name the key they apply to.
+func MapKeys(validators ...tfsdk.AttributeValidator) tfsdk.AttributeValidator {
+	return mapKeysValidator{
+		validators: validators,
+	}
+}
+
+// Description describes this validator.
+func (v mapKeysValidator) Description(ctx context.Context) string {
+	descriptions := make([]string, len(v.validators))
+	for i, validator := range v.validators {
+		descriptions[i] = validator.Description(ctx)
+	}
+	return fmt.Sprintf("For every key: %s", strings.Join(descriptions, " "))
+}
+
+// MarkdownDescription describes this validator.
+func (v mapKeysValidator) MarkdownDescription(ctx context.Context) string {
+	descriptions := make([]string, len(v.validators))
+	for i, validator := range v.validators {
+		descriptions[i] = validator.MarkdownDescription(ctx)
+	}
+	return fmt.Sprintf("For every key: %s", strings.Join(descriptions, " "))
+}
+
+// Validate performs validation on an attribute.
+func (v mapKeysValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	keys, ok := mapKeys(ctx, req, resp, mapKeysErr)
+	if !ok {
+		return
+	}
+
+	for _, key := range keys {
+		keyReq := tfsdk.ValidateAttributeRequest{
+			AttributePath:   req.AttributePath.WithElementKeyString(key),
+			AttributeConfig: types.String{Value: key},
+			Config:          req.Config,
+		}
+
+		reason := fmt.Sprintf("This applies to the key %q.", key)
+		for _, validator := range v.validators {
+			validatorResp := &tfsdk.ValidateAttributeResponse{
+				Diagnostics: diag.Diagnostics{},
+			}
+			validator.Validate(ctx, keyReq, validatorResp)
+
+			for _, d := range validatorResp.Diagnostics {
+				resp.Diagnostics.Append(withReason(d, reason))
+			}
+		}
+	}
+}
+
+type uniqueKeysValidator struct {
+	normalize Normalizer
+	err       error
+}
+
+// UniqueKeys ensures that no two keys of a map are the same once normalized,
+// e.g. UniqueKeys(CaseFold()) treats "Env" and "env" as duplicates. Keys that
+// can't be normalized are compared as they are.
+func UniqueKeys(normalize Normalizer) tfsdk.AttributeValidator {
+	if normalize == nil {
+		return uniqueKeysValidator{
+			err: errors.New("This validator was initialized without a normalizer"),
+		}
+	}
+	return uniqueKeysValidator{
+		normalize: normalize,
+	}
+}
+
+// Description describes this validator.
+func (v uniqueKeysValidator) Description(context.Context) string {
+	if v.normalize == nil {
+		return "Ensures that the keys of the map are unique."
+	}
+	return fmt.Sprintf("Ensures that the keys of the map are unique once %s.", v.normalize.Description())
+}
+
+// MarkdownDescription describes this validator.
+func (v uniqueKeysValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// Validate performs validation on an attribute.
+func (v uniqueKeysValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	if v.err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Unique constraint was violated.",
+			v.err.Error(),
+		)
+		return
+	}
+
+	keys, ok := mapKeys(ctx, req, resp, "Unique constraint was violated.")
+	if !ok {
+		return
+	}
+
+	// The first key with each normalized form.
+	log := map[string]string{}
+
+	for _, key := range keys {
+		normalized, err := v.normalize.Normalize(key)
+		if err != nil {
+			normalized = key
+		}
+
+		first, ok := log[normalized]
+		if !ok {
+			log[normalized] = key
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath.WithElementKeyString(key),
+			"Unique constraint was violated.",
+			fmt.Sprintf(
+				"%s and %s are the same once %s.",
+				formatPath(req.AttributePath.WithElementKeyString(first)),
+				formatPath(req.AttributePath.WithElementKeyString(key)),
+				v.normalize.Description(),
+			),
+		)
+	}
+}
+
+// mapKeys returns the sorted keys of the map being validated. It returns false
+// if the map isn't "set", or isn't a map, in which case the diagnostics say why.
+func mapKeys(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse, summary string) ([]string, bool) {
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			summary,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return nil, false
+	}
+
+	// We don't need to do any validation if the value isn't "set".
+	if !this.IsKnown() || this.IsNull() {
+		return nil, false
+	}
+
+	if !this.Type().Is(tftypes.Map{}) {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			summary,
+			"Unsupported type. Only maps are supported.",
+		)
+		return nil, false
+	}
+
+	var elems map[string]tftypes.Value
+	if err := this.As(&elems); err != nil {
+		resp.Diagnostics.AddError(
+			summary,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return nil, false
+	}
+
+	keys := make([]string, 0, len(elems))
+	for key := range elems {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, true
+}
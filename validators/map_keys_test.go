@@ -0,0 +1,182 @@
+package validators
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMapKeys(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("tags")
+
+	tags := func(keys ...string) types.Map {
+		elems := map[string]attr.Value{}
+		for _, key := range keys {
+			elems[key] = types.String{Value: "x"}
+		}
+		return types.Map{
+			ElemType: types.StringType,
+			Elems:    elems,
+		}
+	}
+
+	for _, test := range []testCase{
+		{
+			name:      "pass",
+			validator: MapKeys(Match(regexp.MustCompile(`^[a-z:]+$`)), MaxLength(8), NoWhitespace(), NoPrefix("aws:")),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: tags("env", "team"),
+			},
+		},
+		{
+			name:      "fail",
+			validator: MapKeys(MaxLength(8), NoPrefix("aws:")),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: tags("aws:name", "env", "organization"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyString("aws:name"),
+					"Invalid String Content",
+					`"aws:name" must not start with the reserved prefix "aws:". This applies to the key "aws:name".`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyString("organization"),
+					"Invalid String Length",
+					`String must be at most 8 characters long. This applies to the key "organization".`,
+				),
+			},
+		},
+		{
+			name:      "unknown",
+			validator: MapKeys(MaxLength(1)),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.Map{
+					ElemType: types.StringType,
+					Unknown:  true,
+				},
+			},
+		},
+		{
+			name:      "unknown values",
+			validator: MapKeys(MaxLength(1)),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.Map{
+					ElemType: types.StringType,
+					Elems:    map[string]attr.Value{"environment": types.String{Unknown: true}},
+				},
+			},
+			err: true,
+		},
+		{
+			name:      "not a map",
+			validator: MapKeys(MaxLength(1)),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("a"),
+			},
+			err: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
+
+func TestUniqueKeys(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("tags")
+
+	tags := func(keys ...string) types.Map {
+		elems := map[string]attr.Value{}
+		for _, key := range keys {
+			elems[key] = types.String{Value: "x"}
+		}
+		return types.Map{
+			ElemType: types.StringType,
+			Elems:    elems,
+		}
+	}
+
+	for _, test := range []testCase{
+		{
+			name:      "pass",
+			validator: UniqueKeys(CaseFold()),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: tags("env", "team"),
+			},
+		},
+		{
+			name:      "fail",
+			validator: UniqueKeys(CaseFold()),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: tags("Env", "env", "ENV", "team"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyString("Env"),
+					"Unique constraint was violated.",
+					`tags["ENV"] and tags["Env"] are the same once case folded.`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyString("env"),
+					"Unique constraint was violated.",
+					`tags["ENV"] and tags["env"] are the same once case folded.`,
+				),
+			},
+		},
+		{
+			name:      "chained",
+			validator: UniqueKeys(Normalize(TrimSpace(), CaseFold())),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: tags("env", " Env"),
+			},
+			err: true,
+		},
+		{
+			name:      "no normalizer",
+			validator: UniqueKeys(nil),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: tags("env"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					"Unique constraint was violated.",
+					"This validator was initialized without a normalizer",
+				),
+			},
+		},
+		{
+			name:      "null",
+			validator: UniqueKeys(CaseFold()),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.Map{
+					ElemType: types.StringType,
+					Null:     true,
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
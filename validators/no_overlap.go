@@ -2,7 +2,11 @@ package validators
 
 import (
 	"context"
-	"strconv"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
 
 	"github.com/frankgreco/terraform-helpers/internal/overlap"
 	"github.com/frankgreco/terraform-helpers/internal/utils"
@@ -18,11 +22,27 @@ const (
 	noOverlapDescription = "Ensures that no items overlap with any other in the list."
 )
 
-type noOverlapValidator struct{}
+type noOverlapValidator struct {
+	lower, upper string
+}
 
 // NoOverlap ensures that no elements overlap with any other in the list.
+// The elements are either numbers or objects whose "from" and "to"
+// attributes bound a range.
 func NoOverlap() tfsdk.AttributeValidator {
-	return noOverlapValidator{}
+	return NoOverlapBounds("from", "to")
+}
+
+// NoOverlapBounds ensures that no objects in the list overlap with any other,
+// where the range of each object is bounded by its lower and upper attributes.
+// The bounds may be numbers, IP addresses or RFC 3339 timestamps, must be of
+// the same kind (an IPv4 and an IPv6 address are not) and must satisfy
+// lower <= upper.
+func NoOverlapBounds(lower, upper string) tfsdk.AttributeValidator {
+	return noOverlapValidator{
+		lower: lower,
+		upper: upper,
+	}
 }
 
 // Description describes this validator.
@@ -48,16 +68,31 @@ func (v noOverlapValidator) Validate(ctx context.Context, req tfsdk.ValidateAttr
 		}
 	}
 
-	// Object
-	if (tftypes.Object{}).Is(list.Type(ctx).(types.ListType).ElementType().TerraformType(ctx)) {
-		resp.Diagnostics.Append(validateObjectList(ctx, list)...)
+	// The elements are walked as terraform values, as decoding them into
+	// Go values doesn't cope with null or unknown elements.
+	this, err := toValue(ctx, list)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			noOverlapErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	var elems []tftypes.Value
+	if err := this.As(&elems); err != nil {
+		resp.Diagnostics.AddError(
+			noOverlapErr,
+			"The validator had an internal error: "+err.Error(),
+		)
 		return
 	}
 
-	// Number
-	switch list.Type(ctx).(types.ListType).ElementType() {
-	case types.NumberType:
-		resp.Diagnostics.Append(validateNumberList(ctx, list)...)
+	switch elemType := list.ElemType.TerraformType(ctx); {
+	case elemType.Is(tftypes.Object{}):
+		resp.Diagnostics.Append(v.validateObjectList(req.AttributePath, elems)...)
+	case elemType.Is(tftypes.Number):
+		resp.Diagnostics.Append(validateNumberList(req.AttributePath, elems)...)
 	default:
 		resp.Diagnostics.AddError(
 			noOverlapErr,
@@ -67,50 +102,159 @@ func (v noOverlapValidator) Validate(ctx context.Context, req tfsdk.ValidateAttr
 	}
 }
 
-func validateObjectList(ctx context.Context, list types.List) (diags diag.Diagnostics) {
-	var items []types.Object
-	{
-		diags.Append(list.ElementsAs(ctx, &items, false)...)
-		if diags.HasError() {
-			return
+func (v noOverlapValidator) validateObjectList(path *tftypes.AttributePath, items []tftypes.Value) (diags diag.Diagnostics) {
+	var pairs []utils.OrderedPair
+	var originals []string
+	var paths []*tftypes.AttributePath
+	for i, value := range items {
+		if !value.IsKnown() || value.IsNull() {
+			continue
 		}
-	}
 
-	var pairs []utils.OrderedPair
-	for _, item := range items {
-		aux := struct {
-			From int `tfsdk:"from"`
-			To   int `tfsdk:"to"`
-		}{}
-		diags.Append(item.As(ctx, &aux, types.ObjectAsOptions{})...)
-		if diags.HasError() {
+		var attrs map[string]tftypes.Value
+		if err := value.As(&attrs); err != nil {
+			diags.AddError(
+				noOverlapErr,
+				"The validator had an internal error: "+err.Error(),
+			)
 			return
 		}
-		pairs = append(pairs, utils.NewOrderedPair(strconv.Itoa(aux.From), strconv.Itoa(aux.To)))
+
+		lower, lowerOk, err := boundKey(attrs, v.lower)
+		if err != nil {
+			diags.AddAttributeError(path.WithElementKeyInt(i), noOverlapErr, err.Error())
+			continue
+		}
+		upper, upperOk, err := boundKey(attrs, v.upper)
+		if err != nil {
+			diags.AddAttributeError(path.WithElementKeyInt(i), noOverlapErr, err.Error())
+			continue
+		}
+
+		// The range can't be checked until both bounds are "set".
+		if !lowerOk || !upperOk {
+			continue
+		}
+
+		if !utils.SameKind(lower, upper) {
+			diags.AddAttributeError(
+				path.WithElementKeyInt(i),
+				noOverlapErr,
+				fmt.Sprintf("%s (%s) and %s (%s) must both be numbers, IPv4 addresses, IPv6 addresses or timestamps.", v.lower, lower, v.upper, upper),
+			)
+			continue
+		}
+		if lower.Compare(upper) > 0 {
+			diags.AddAttributeError(
+				path.WithElementKeyInt(i),
+				"Invalid Range",
+				fmt.Sprintf("%s (%s) must be less than or equal to %s (%s).", v.lower, lower, v.upper, upper),
+			)
+			continue
+		}
+		if len(pairs) > 0 && !utils.SameKind(pairs[0].First(), lower) {
+			diags.AddAttributeError(
+				path.WithElementKeyInt(i),
+				noOverlapErr,
+				fmt.Sprintf("%s (%s) must be of the same type as every other %s (%s).", v.lower, lower, v.lower, pairs[0].First()),
+			)
+			continue
+		}
+
+		pairs = append(pairs, utils.NewOrderedPair(lower, upper))
+		originals = append(originals, formatValue(value))
+		paths = append(paths, path.WithElementKeyInt(i))
 	}
 
-	if err := overlap.OrderedPairs(pairs); err != nil {
-		diags.AddError(noOverlapErr, err.Error())
+	if diags.HasError() {
 		return
 	}
+
+	diags.Append(overlapDiagnostics(noOverlapErr, overlap.OrderedPairs(pairs), paths, originals)...)
 	return
 }
 
-func validateNumberList(ctx context.Context, list types.List) (diags diag.Diagnostics) {
-	var encoded []int
-	{
-		diags.Append(list.ElementsAs(ctx, &encoded, false)...)
-		if diags.HasError() {
+// boundKey returns the key for the named attribute of an object. ok is false
+// if the attribute is not "set".
+func boundKey(attrs map[string]tftypes.Value, name string) (key utils.Key, ok bool, err error) {
+	value, found := attrs[name]
+	if !found {
+		return nil, false, fmt.Errorf("The object does not have the attribute %s.", name)
+	}
+
+	if !value.IsKnown() || value.IsNull() {
+		return nil, false, nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.Number):
+		var number big.Float
+		if err := value.As(&number); err != nil {
+			return nil, false, errors.New("The validator had an internal error: " + err.Error())
+		}
+		// Whole numbers are discrete, which lets the ranges be walked for gaps.
+		if i, accuracy := number.Int64(); accuracy == big.Exact {
+			return utils.NewIntKey(i), true, nil
+		}
+		return utils.NewNumberKey(&number), true, nil
+	case value.Type().Is(tftypes.String):
+		var str string
+		if err := value.As(&str); err != nil {
+			return nil, false, errors.New("The validator had an internal error: " + err.Error())
+		}
+		if ip := net.ParseIP(str); ip != nil {
+			return utils.NewIPKey(ip), true, nil
+		}
+		if t, err := time.Parse(time.RFC3339, str); err == nil {
+			return utils.NewTimeKey(t), true, nil
+		}
+		return nil, false, fmt.Errorf("%s (%q) must be an IP address or an RFC 3339 timestamp.", name, str)
+	}
+	return nil, false, fmt.Errorf("%s must be a number or a string.", name)
+}
+
+func validateNumberList(path *tftypes.AttributePath, items []tftypes.Value) (diags diag.Diagnostics) {
+	var encoded []*big.Float
+	var originals []string
+	var paths []*tftypes.AttributePath
+	for i, item := range items {
+		// An element that isn't "set" can't overlap with anything.
+		if !item.IsKnown() || item.IsNull() {
+			continue
+		}
+
+		// A zero precision target takes on the precision of the value.
+		number := new(big.Float)
+		if err := item.As(number); err != nil {
+			diags.AddError(
+				noOverlapErr,
+				"The validator had an internal error: "+err.Error(),
+			)
 			return
 		}
+
+		encoded = append(encoded, number)
+		originals = append(originals, formatNumber(number))
+		paths = append(paths, path.WithElementKeyInt(i))
 	}
 
-	if err := overlap.IntSlice(encoded); err != nil {
-		diags.AddError(
-			noOverlapErr,
-			err.Error(),
+	diags.Append(overlapDiagnostics(noOverlapErr, overlap.NumberSlice(encoded), paths, originals)...)
+	return
+}
+
+// overlapDiagnostics returns a diagnostic for every overlap. Each diagnostic
+// is attached to the later of the two elements and names the earlier one.
+func overlapDiagnostics(summary string, overlaps []utils.Overlap, paths []*tftypes.AttributePath, originals []string) (diags diag.Diagnostics) {
+	for _, o := range overlaps {
+		diags.AddAttributeError(
+			paths[o.Right],
+			summary,
+			fmt.Sprintf(
+				"%s (%s) overlaps with %s (%s).",
+				formatPath(paths[o.Right]), originals[o.Right],
+				formatPath(paths[o.Left]), originals[o.Left],
+			),
 		)
-		return
 	}
 	return
 }
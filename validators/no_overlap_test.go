@@ -0,0 +1,305 @@
+package validators
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNoOverlap(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("ranges")
+
+	for _, test := range []testCase{
+		{
+			name:      "numbers pass",
+			validator: NoOverlap(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: numberList(9, 10, 1.2, 1.7),
+			},
+		},
+		{
+			name:      "numbers fail",
+			validator: NoOverlap(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: numberList(10, 9, 1.5, 10),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(3),
+					noOverlapErr,
+					"ranges[3] (10) overlaps with ranges[0] (10).",
+				),
+			},
+		},
+		{
+			name:      "null and unknown numbers",
+			validator: NoOverlap(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.NumberType,
+					Elems: []attr.Value{
+						types.Number{Value: big.NewFloat(10)},
+						types.Number{Null: true},
+						types.Number{Unknown: true},
+						types.Number{Null: true},
+						types.Number{Value: big.NewFloat(10)},
+					},
+				},
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(4),
+					noOverlapErr,
+					"ranges[4] (10) overlaps with ranges[0] (10).",
+				),
+			},
+		},
+		{
+			name:      "null bounds",
+			validator: NoOverlapBounds("start_port", "end_port"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"start_port": types.Number{Value: big.NewFloat(80)}, "end_port": types.Number{Null: true}},
+					map[string]attr.Value{"start_port": types.Number{Null: true}, "end_port": types.Number{Value: big.NewFloat(80)}},
+					map[string]attr.Value{"start_port": types.Number{Value: big.NewFloat(79.5)}, "end_port": types.Number{Value: big.NewFloat(80.5)}},
+				),
+			},
+		},
+		{
+			name:      "objects pass",
+			validator: NoOverlap(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: fromToList([2]int64{10, 19}, [2]int64{1, 9}, [2]int64{20, 100}),
+			},
+		},
+		{
+			name:      "objects fail",
+			validator: NoOverlap(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: fromToList([2]int64{1, 10}, [2]int64{20, 30}, [2]int64{9, 21}),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					noOverlapErr,
+					"ranges[2] ({from = 9, to = 21}) overlaps with ranges[0] ({from = 1, to = 10}).",
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					noOverlapErr,
+					"ranges[2] ({from = 9, to = 21}) overlaps with ranges[1] ({from = 20, to = 30}).",
+				),
+			},
+		},
+		{
+			name:      "named number bounds",
+			validator: NoOverlapBounds("start_port", "end_port"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"start_port": types.Number{Value: big.NewFloat(80)}, "end_port": types.Number{Value: big.NewFloat(80)}},
+					map[string]attr.Value{"start_port": types.Number{Value: big.NewFloat(8000)}, "end_port": types.Number{Value: big.NewFloat(8080)}},
+					map[string]attr.Value{"start_port": types.Number{Value: big.NewFloat(443)}, "end_port": types.Number{Unknown: true}},
+				),
+			},
+		},
+		{
+			name:      "ip bounds",
+			validator: NoOverlapBounds("first", "last"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"first": types.String{Value: "10.0.0.10"}, "last": types.String{Value: "10.0.0.99"}},
+					map[string]attr.Value{"first": types.String{Value: "10.0.0.100"}, "last": types.String{Value: "10.0.0.200"}},
+					map[string]attr.Value{"first": types.String{Value: "10.0.0.9"}, "last": types.String{Value: "10.0.0.10"}},
+				),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					noOverlapErr,
+					`ranges[2] ({first = "10.0.0.9", last = "10.0.0.10"}) overlaps with ranges[0] ({first = "10.0.0.10", last = "10.0.0.99"}).`,
+				),
+			},
+		},
+		{
+			name:      "timestamp bounds",
+			validator: NoOverlapBounds("start_time", "end_time"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"start_time": types.String{Value: "2022-01-01T00:00:00Z"}, "end_time": types.String{Value: "2022-01-01T12:00:00Z"}},
+					map[string]attr.Value{"start_time": types.String{Value: "2022-01-01T13:30:00+01:00"}, "end_time": types.String{Value: "2022-01-02T00:00:00Z"}},
+				),
+			},
+		},
+		{
+			name:      "timestamp bounds overlap",
+			validator: NoOverlapBounds("start_time", "end_time"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"start_time": types.String{Value: "2022-01-01T00:00:00Z"}, "end_time": types.String{Value: "2022-01-01T12:00:00Z"}},
+					map[string]attr.Value{"start_time": types.String{Value: "2022-01-01T12:00:00+01:00"}, "end_time": types.String{Value: "2022-01-02T00:00:00Z"}},
+				),
+			},
+			err: true,
+		},
+		{
+			name:      "lower greater than upper",
+			validator: NoOverlapBounds("start_port", "end_port"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"start_port": types.Number{Value: big.NewFloat(8080)}, "end_port": types.Number{Value: big.NewFloat(8000)}},
+				),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(0),
+					"Invalid Range",
+					"start_port (8080) must be less than or equal to end_port (8000).",
+				),
+			},
+		},
+		{
+			name:      "mixed bound types",
+			validator: NoOverlapBounds("first", "last"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"first": types.String{Value: "10.0.0.1"}, "last": types.String{Value: "2022-01-01T00:00:00Z"}},
+				),
+			},
+			err: true,
+		},
+		{
+			name:      "mixed ip families",
+			validator: NoOverlapBounds("first", "last"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"first": types.String{Value: "10.0.0.1"}, "last": types.String{Value: "::1"}},
+				),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(0),
+					noOverlapErr,
+					"first (10.0.0.1) and last (::1) must both be numbers, IPv4 addresses, IPv6 addresses or timestamps.",
+				),
+			},
+		},
+		{
+			name:      "invalid bound",
+			validator: NoOverlapBounds("first", "last"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"first": types.String{Value: "ten"}, "last": types.String{Value: "10.0.0.1"}},
+				),
+			},
+			err: true,
+		},
+		{
+			name:      "missing bound",
+			validator: NoOverlapBounds("start", "end"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: fromToList([2]int64{1, 10}),
+			},
+			err: true,
+		},
+		{
+			name:      "null",
+			validator: NoOverlap(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.NumberType,
+					Null:     true,
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
+
+func numberList(values ...float64) types.List {
+	elems := make([]attr.Value, len(values))
+	for i, value := range values {
+		elems[i] = types.Number{Value: big.NewFloat(value)}
+	}
+	return types.List{
+		ElemType: types.NumberType,
+		Elems:    elems,
+	}
+}
+
+var fromToType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"from": types.NumberType,
+		"to":   types.NumberType,
+	},
+}
+
+func fromToList(values ...[2]int64) types.List {
+	elems := make([]attr.Value, len(values))
+	for i, value := range values {
+		elems[i] = types.Object{
+			AttrTypes: fromToType.AttrTypes,
+			Attrs: map[string]attr.Value{
+				"from": types.Number{Value: big.NewFloat(float64(value[0]))},
+				"to":   types.Number{Value: big.NewFloat(float64(value[1]))},
+			},
+		}
+	}
+	return types.List{
+		ElemType: fromToType,
+		Elems:    elems,
+	}
+}
+
+// objectList returns a list of objects, the attribute types
+// of which are taken from the first object.
+func objectList(objects ...map[string]attr.Value) types.List {
+	ctx := context.Background()
+
+	attrTypes := map[string]attr.Type{}
+	for name, value := range objects[0] {
+		attrTypes[name] = value.Type(ctx)
+	}
+
+	elems := make([]attr.Value, len(objects))
+	for i, object := range objects {
+		elems[i] = types.Object{
+			AttrTypes: attrTypes,
+			Attrs:     object,
+		}
+	}
+	return types.List{
+		ElemType: types.ObjectType{AttrTypes: attrTypes},
+		Elems:    elems,
+	}
+}
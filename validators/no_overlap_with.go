@@ -0,0 +1,147 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/frankgreco/terraform-helpers/internal/overlap"
+	"github.com/frankgreco/terraform-helpers/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+const (
+	noOverlapWithErr         = "There was an overlap detected."
+	noOverlapWithDescription = "Ensures that no CIDRs overlap with any CIDR of the specified attributes."
+)
+
+type noOverlapWithValidator struct {
+	attributes []string
+}
+
+// NoOverlapWith ensures that the CIDR, or every CIDR in the list or set, does not
+// overlap with any CIDR of the specified attributes. The attributes are path
+// expressions, e.g. "node_cidr" at the same level or "../vpc/cidrs" one level up.
+// Each of those attributes may be either a CIDR or a list or set of CIDRs.
+func NoOverlapWith(attributes ...string) tfsdk.AttributeValidator {
+	return noOverlapWithValidator{
+		attributes: attributes,
+	}
+}
+
+// Description describes this validator.
+func (v noOverlapWithValidator) Description(context.Context) string {
+	return noOverlapWithDescription
+}
+
+// MarkdownDescription describes this validator.
+func (v noOverlapWithValidator) MarkdownDescription(context.Context) string {
+	return noOverlapWithDescription
+}
+
+// Validate performs validation on an attribute.
+func (v noOverlapWithValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	if len(v.attributes) == 0 {
+		return
+	}
+
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			noOverlapWithErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	encoded, paths, known, err := stringElements(this, req.AttributePath)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			noOverlapWithErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	// We don't need to do any validation if the value isn't "set".
+	if !known || len(encoded) == 0 {
+		return
+	}
+
+	cidrs, err := parseCIDRs(encoded)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			noOverlapWithErr,
+			err.Error(),
+		)
+		return
+	}
+	ours := len(cidrs)
+
+	for _, attribute := range v.attributes {
+		value, path, ok, diags := getAttribute(ctx, req, attribute)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+		if !ok {
+			continue
+		}
+
+		theirEncoded, theirPaths, known, err := stringElements(value, path)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath,
+				noOverlapWithErr,
+				"The validator had an internal error: "+err.Error(),
+			)
+			return
+		}
+
+		// Check if the attribute is "actually" set.
+		if !known {
+			continue
+		}
+
+		theirs, err := parseCIDRs(theirEncoded)
+		if err != nil {
+			// An invalid CIDR is reported by that attribute's own validators.
+			continue
+		}
+
+		cidrs = append(cidrs, theirs...)
+		encoded = append(encoded, theirEncoded...)
+		paths = append(paths, theirPaths...)
+	}
+
+	// Overlaps between our own CIDRs are left to NoOverlappingCIDRs.
+	for _, o := range overlap.OrderedPairs(cidrs) {
+		if o.Left >= ours || o.Right < ours {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			paths[o.Left],
+			noOverlapWithErr,
+			fmt.Sprintf(
+				"%s (%s) overlaps with %s (%s).",
+				formatPath(paths[o.Left]), strconv.Quote(encoded[o.Left]),
+				formatPath(paths[o.Right]), strconv.Quote(encoded[o.Right]),
+			),
+		)
+	}
+}
+
+func parseCIDRs(encoded []string) ([]utils.OrderedPair, error) {
+	cidrs := make([]utils.OrderedPair, len(encoded))
+	for i, cidr := range encoded {
+		pair, err := overlap.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		cidrs[i] = pair
+	}
+	return cidrs, nil
+}
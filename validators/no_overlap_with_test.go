@@ -0,0 +1,93 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNoOverlapWith(t *testing.T) {
+	podCidrs := tftypes.NewAttributePath().WithAttributeName("pod_cidrs")
+
+	for _, test := range []testCase{
+		{
+			name:      "pass",
+			validator: NoOverlapWith("service_cidrs", "node_cidr"),
+			request: testRequest("pod_cidrs", map[string]attr.Value{
+				"pod_cidrs":     stringList("10.0.0.0/16", "10.1.0.0/16"),
+				"service_cidrs": stringList("10.2.0.0/16"),
+				"node_cidr":     types.String{Value: "192.168.0.0/24"},
+			}),
+		},
+		{
+			name:      "fail",
+			validator: NoOverlapWith("service_cidrs", "node_cidr"),
+			request: testRequest("pod_cidrs", map[string]attr.Value{
+				"pod_cidrs":     stringList("10.0.0.0/16", "10.1.0.0/16"),
+				"service_cidrs": stringList("10.2.0.0/16", "10.1.128.0/24"),
+				"node_cidr":     types.String{Value: "10.0.0.1"},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					podCidrs.WithElementKeyInt(0),
+					noOverlapWithErr,
+					`pod_cidrs[0] ("10.0.0.0/16") overlaps with node_cidr ("10.0.0.1").`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					podCidrs.WithElementKeyInt(1),
+					noOverlapWithErr,
+					`pod_cidrs[1] ("10.1.0.0/16") overlaps with service_cidrs[1] ("10.1.128.0/24").`,
+				),
+			},
+		},
+		{
+			name:      "own overlaps are ignored",
+			validator: NoOverlapWith("service_cidrs"),
+			request: testRequest("pod_cidrs", map[string]attr.Value{
+				"pod_cidrs":     stringList("10.0.0.0/16", "10.0.0.0/24"),
+				"service_cidrs": stringList("10.2.0.0/16"),
+			}),
+		},
+		{
+			name:      "unknown sibling",
+			validator: NoOverlapWith("service_cidrs"),
+			request: testRequest("pod_cidrs", map[string]attr.Value{
+				"pod_cidrs":     stringList("10.0.0.0/16"),
+				"service_cidrs": types.List{ElemType: types.StringType, Unknown: true},
+			}),
+		},
+		{
+			name:      "invalid sibling",
+			validator: NoOverlapWith("service_cidrs"),
+			request: testRequest("pod_cidrs", map[string]attr.Value{
+				"pod_cidrs":     stringList("10.0.0.0/16"),
+				"service_cidrs": stringList("10.0.0.0/33"),
+			}),
+		},
+		{
+			name:      "invalid",
+			validator: NoOverlapWith("service_cidrs"),
+			request: testRequest("pod_cidrs", map[string]attr.Value{
+				"pod_cidrs":     stringList("10.0.0.0/33"),
+				"service_cidrs": stringList("10.0.0.0/16"),
+			}),
+			err: true,
+		},
+		{
+			name:      "null",
+			validator: NoOverlapWith("service_cidrs"),
+			request: testRequest("pod_cidrs", map[string]attr.Value{
+				"pod_cidrs":     types.List{ElemType: types.StringType, Null: true},
+				"service_cidrs": stringList("10.0.0.0/16"),
+			}),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
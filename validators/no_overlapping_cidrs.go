@@ -2,16 +2,18 @@ package validators
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/frankgreco/terraform-helpers/internal/overlap"
 
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 const (
 	noOverlappingCIDRsErr         = "There was an overlap detected."
-	noOverlappingCIDRsDescription = "Ensures that no CIDRs overlap with any other in the list."
+	noOverlappingCIDRsDescription = "Ensures that no CIDRs overlap with any other in the list. IPv4 and IPv6 CIDRs are checked separately."
 )
 
 type noOverlappingCIDRsValidator struct{}
@@ -33,7 +35,6 @@ func (v noOverlappingCIDRsValidator) MarkdownDescription(context.Context) string
 
 // Validate performs validation on an attribute.
 func (v noOverlappingCIDRsValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
-
 	var list types.List
 	{
 		resp.Diagnostics.Append(tfsdk.ValueAs(ctx, req.AttributeConfig, &list)...)
@@ -57,12 +58,22 @@ func (v noOverlappingCIDRsValidator) Validate(ctx context.Context, req tfsdk.Val
 		}
 	}
 
-	if err := overlap.CIDR(encoded); err != nil {
-		resp.Diagnostics.AddError(
+	overlaps, err := overlap.CIDR(encoded)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
 			noOverlappingCIDRsErr,
 			err.Error(),
 		)
 		return
 	}
 
+	originals := make([]string, len(encoded))
+	paths := make([]*tftypes.AttributePath, len(encoded))
+	for i, cidr := range encoded {
+		originals[i] = strconv.Quote(cidr)
+		paths[i] = req.AttributePath.WithElementKeyInt(i)
+	}
+
+	resp.Diagnostics.Append(overlapDiagnostics(noOverlappingCIDRsErr, overlaps, paths, originals)...)
 }
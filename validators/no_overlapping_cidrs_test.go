@@ -0,0 +1,98 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNoOverlappingCIDRs(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("cidrs")
+
+	for _, test := range []testCase{
+		{
+			name:      "pass",
+			validator: NoOverlappingCIDRs(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("10.0.0.0/16", "10.1.0.0/16", "2001:db8::/32"),
+			},
+		},
+		{
+			name:      "every overlap is reported",
+			validator: NoOverlappingCIDRs(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("10.0.0.0/8", "192.168.0.0/24", "10.1.0.0/16", "10.1.2.3"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					noOverlappingCIDRsErr,
+					`cidrs[2] ("10.1.0.0/16") overlaps with cidrs[0] ("10.0.0.0/8").`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(3),
+					noOverlappingCIDRsErr,
+					`cidrs[3] ("10.1.2.3") overlaps with cidrs[0] ("10.0.0.0/8").`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(3),
+					noOverlappingCIDRsErr,
+					`cidrs[3] ("10.1.2.3") overlaps with cidrs[2] ("10.1.0.0/16").`,
+				),
+			},
+		},
+		{
+			name:      "invalid cidr",
+			validator: NoOverlappingCIDRs(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("10.0.0.0/8", "10.0.0.0/33"),
+			},
+			err: true,
+		},
+		{
+			name:      "null",
+			validator: NoOverlappingCIDRs(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.StringType,
+					Null:     true,
+				},
+			},
+		},
+		{
+			name:      "unknown",
+			validator: NoOverlappingCIDRs(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.StringType,
+					Unknown:  true,
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
+
+func stringList(values ...string) types.List {
+	elems := make([]attr.Value, len(values))
+	for i, value := range values {
+		elems[i] = types.String{Value: value}
+	}
+	return types.List{
+		ElemType: types.StringType,
+		Elems:    elems,
+	}
+}
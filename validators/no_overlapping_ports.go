@@ -0,0 +1,182 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/frankgreco/terraform-helpers/internal/overlap"
+	"github.com/frankgreco/terraform-helpers/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	noOverlappingPortsErr         = "There was an overlap detected."
+	noOverlappingPortsDescription = "Ensures that no port ranges overlap with any other in the list that shares the same protocol."
+)
+
+type noOverlappingPortsValidator struct {
+	port, protocol string
+}
+
+// NoOverlappingPorts ensures that, given a list or set of objects, the ports of
+// no two objects sharing the same protocol overlap. The port attribute may be a
+// port or port range string (e.g. "8000-8080") or a number. The protocols are
+// compared case insensitively, an empty protocol attribute name treats every
+// object as sharing the same protocol.
+func NoOverlappingPorts(port, protocol string) tfsdk.AttributeValidator {
+	return noOverlappingPortsValidator{
+		port:     port,
+		protocol: protocol,
+	}
+}
+
+// Description describes this validator.
+func (v noOverlappingPortsValidator) Description(context.Context) string {
+	return noOverlappingPortsDescription
+}
+
+// MarkdownDescription describes this validator.
+func (v noOverlappingPortsValidator) MarkdownDescription(context.Context) string {
+	return noOverlappingPortsDescription
+}
+
+// Validate performs validation on an attribute.
+func (v noOverlappingPortsValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			noOverlappingPortsErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	if !this.IsKnown() || this.IsNull() {
+		return
+	}
+
+	isList := this.Type().Is(tftypes.List{})
+	if !isList && !this.Type().Is(tftypes.Set{}) {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			noOverlappingPortsErr,
+			"Unsupported type. Only lists and sets of objects are supported.",
+		)
+		return
+	}
+
+	var elems []tftypes.Value
+	if err := this.As(&elems); err != nil {
+		resp.Diagnostics.AddError(
+			noOverlappingPortsErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	var pairs []utils.OrderedPair
+	var protocols, originals []string
+	var paths []*tftypes.AttributePath
+	for i, elem := range elems {
+		path := req.AttributePath.WithElementKeyValue(elem)
+		if isList {
+			path = req.AttributePath.WithElementKeyInt(i)
+		}
+
+		if !elem.IsKnown() || elem.IsNull() {
+			continue
+		}
+
+		var attrs map[string]tftypes.Value
+		if err := elem.As(&attrs); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path,
+				noOverlappingPortsErr,
+				"Unsupported type. Only lists and sets of objects are supported.",
+			)
+			return
+		}
+
+		port, ok := attrs[v.port]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path,
+				noOverlappingPortsErr,
+				fmt.Sprintf("The object does not have the attribute %s.", v.port),
+			)
+			return
+		}
+
+		pair, ok, err := portPair(port)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.WithAttributeName(v.port),
+				portRangeErr,
+				err.Error(),
+			)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		protocol, ok, err := v.protocolOf(attrs)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path,
+				noOverlappingPortsErr,
+				err.Error(),
+			)
+			return
+		}
+		if !ok {
+			continue
+		}
+
+		pairs = append(pairs, pair)
+		protocols = append(protocols, protocol)
+		originals = append(originals, formatValue(elem))
+		paths = append(paths, path)
+	}
+
+	var overlaps []utils.Overlap
+	for _, o := range overlap.OrderedPairs(pairs) {
+		if protocols[o.Left] == protocols[o.Right] {
+			overlaps = append(overlaps, o)
+		}
+	}
+
+	resp.Diagnostics.Append(overlapDiagnostics(noOverlappingPortsErr, overlaps, paths, originals)...)
+}
+
+// protocolOf returns the normalized protocol of the object. ok is false
+// if the protocol isn't "set".
+func (v noOverlappingPortsValidator) protocolOf(attrs map[string]tftypes.Value) (protocol string, ok bool, err error) {
+	if v.protocol == "" {
+		return "", true, nil
+	}
+
+	value, found := attrs[v.protocol]
+	if !found {
+		return "", false, fmt.Errorf("The object does not have the attribute %s.", v.protocol)
+	}
+	if !value.Type().Is(tftypes.String) {
+		return "", false, fmt.Errorf("The attribute %s must be a string.", v.protocol)
+	}
+	if !value.IsKnown() {
+		return "", false, nil
+	}
+
+	// A null protocol is still a protocol, it likely means the default.
+	if value.IsNull() {
+		return "", true, nil
+	}
+	if err := value.As(&protocol); err != nil {
+		return "", false, errors.New("The validator had an internal error: " + err.Error())
+	}
+	return strings.ToLower(protocol), true, nil
+}
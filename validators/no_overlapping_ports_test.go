@@ -0,0 +1,136 @@
+package validators
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNoOverlappingPorts(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("rules")
+
+	rule := func(protocol, port string) map[string]attr.Value {
+		return map[string]attr.Value{
+			"protocol": types.String{Value: protocol},
+			"port":     types.String{Value: port},
+		}
+	}
+
+	for _, test := range []testCase{
+		{
+			name:      "pass",
+			validator: NoOverlappingPorts("port", "protocol"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					rule("tcp", "80"),
+					rule("udp", "80"),
+					rule("tcp", "8000-8080"),
+					rule("udp", "53"),
+				),
+			},
+		},
+		{
+			name:      "fail",
+			validator: NoOverlappingPorts("port", "protocol"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					rule("tcp", "8000-8080"),
+					rule("udp", "8080"),
+					rule("TCP", "8080-9000"),
+				),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					noOverlappingPortsErr,
+					`rules[2] ({port = "8080-9000", protocol = "TCP"}) overlaps with rules[0] ({port = "8000-8080", protocol = "tcp"}).`,
+				),
+			},
+		},
+		{
+			name:      "without protocol",
+			validator: NoOverlappingPorts("port", ""),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"port": types.Number{Value: big.NewFloat(80)}},
+					map[string]attr.Value{"port": types.Number{Value: big.NewFloat(80)}},
+				),
+			},
+			err: true,
+		},
+		{
+			name:      "invalid port",
+			validator: NoOverlappingPorts("port", "protocol"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					rule("tcp", "80"),
+					rule("tcp", "65536"),
+				),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(1).WithAttributeName("port"),
+					portRangeErr,
+					`Invalid port range "65536": 65536 must be between 1 and 65535`,
+				),
+			},
+		},
+		{
+			name:      "unknown protocol",
+			validator: NoOverlappingPorts("port", "protocol"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					rule("tcp", "80"),
+					map[string]attr.Value{
+						"protocol": types.String{Unknown: true},
+						"port":     types.String{Value: "80"},
+					},
+				),
+			},
+		},
+		{
+			name:      "missing attribute",
+			validator: NoOverlappingPorts("port", "proto"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: objectList(rule("tcp", "80")),
+			},
+			err: true,
+		},
+		{
+			name:      "null",
+			validator: NoOverlappingPorts("port", "protocol"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{"port": types.StringType, "protocol": types.StringType}},
+					Null:     true,
+				},
+			},
+		},
+		{
+			name:      "wrong type",
+			validator: NoOverlappingPorts("port", "protocol"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Value: "80"},
+			},
+			err: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	noPrefixErr         = "%q must not start with the reserved prefix %q."
+	noPrefixDescription = "Ensure that the attribute value does not start with a reserved prefix."
+)
+
+type noPrefixValidator struct {
+	prefixes []string
+}
+
+// NoPrefix ensures that the string does not start with any of the reserved
+// prefixes, e.g. NoPrefix("aws:"). Prefixes are matched regardless of case, so
+// "AWS:name" is reserved too. Use it with MapKeys for the keys of a map.
+func NoPrefix(prefixes ...string) tfsdk.AttributeValidator {
+	return noPrefixValidator{
+		prefixes: prefixes,
+	}
+}
+
+func (v noPrefixValidator) Description(context.Context) string {
+	return noPrefixDescription
+}
+
+func (v noPrefixValidator) MarkdownDescription(context.Context) string {
+	return noPrefixDescription
+}
+
+func (v noPrefixValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var str types.String
+	{
+		diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &str)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	if str.Unknown || str.Null {
+		return
+	}
+
+	for _, prefix := range v.prefixes {
+		if strings.HasPrefix(strings.ToLower(str.Value), strings.ToLower(prefix)) {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath,
+				"Invalid String Content",
+				fmt.Sprintf(noPrefixErr, str.Value, prefix),
+			)
+			return
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNoPrefix(t *testing.T) {
+	for _, test := range []testCase{
+		{
+			name:      "pass",
+			validator: NoPrefix("aws:", "kubernetes.io/"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{
+					Value: "team",
+				},
+			},
+		},
+		{
+			name:      "fail",
+			validator: NoPrefix("aws:", "kubernetes.io/"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{
+					Value: "kubernetes.io/name",
+				},
+			},
+			err: true,
+		},
+		{
+			name:      "fail regardless of case",
+			validator: NoPrefix("aws:"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{
+					Value: "AWS:foo",
+				},
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					nil,
+					"Invalid String Content",
+					`"AWS:foo" must not start with the reserved prefix "aws:".`,
+				),
+			},
+		},
+		{
+			name:      "null",
+			validator: NoPrefix("aws:"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{
+					Null: true,
+				},
+			},
+		},
+		{
+			name:      "unknown",
+			validator: NoPrefix("aws:"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{
+					Unknown: true,
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package validators
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/frankgreco/terraform-helpers/internal/overlap"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer transforms a string into a canonical form, so that
+// spellings of the same value compare as equal.
+type Normalizer interface {
+	// Description describes the canonical form, e.g. "case folded".
+	Description() string
+
+	// Normalize returns the canonical form of the string, or an
+	// error if the string has no canonical form, e.g. an invalid CIDR.
+	Normalize(s string) (string, error)
+}
+
+type normalizer struct {
+	description string
+	normalize   func(string) (string, error)
+}
+
+func (n normalizer) Description() string {
+	return n.description
+}
+
+func (n normalizer) Normalize(s string) (string, error) {
+	return n.normalize(s)
+}
+
+// CaseFold normalizes strings by Unicode case folding,
+// e.g. "Example.COM" becomes "example.com".
+func CaseFold() Normalizer {
+	return normalizer{
+		description: "case folded",
+		normalize: func(s string) (string, error) {
+			return cases.Fold().String(s), nil
+		},
+	}
+}
+
+// TrimSpace normalizes strings by removing leading and trailing whitespace.
+func TrimSpace() Normalizer {
+	return normalizer{
+		description: "trimmed",
+		normalize: func(s string) (string, error) {
+			return strings.TrimSpace(s), nil
+		},
+	}
+}
+
+// NFC normalizes strings to Unicode normalization form C, so that
+// precomposed and decomposed characters (é and e + ◌́) are the same.
+func NFC() Normalizer {
+	return normalizer{
+		description: "in Unicode normalization form C",
+		normalize: func(s string) (string, error) {
+			return norm.NFC.String(s), nil
+		},
+	}
+}
+
+// CanonicalCIDR normalizes CIDRs to their network address and mask,
+// e.g. "10.0.0.1/24" becomes "10.0.0.0/24". IPv6 addresses are compressed
+// and an address without a mask is a single host, e.g. "10.0.0.1/32".
+func CanonicalCIDR() Normalizer {
+	return normalizer{
+		description: "canonical CIDRs",
+		normalize:   overlap.CanonicalCIDR,
+	}
+}
+
+// CanonicalIP normalizes IP addresses to their shortest form, which
+// compresses IPv6 addresses, e.g. "2001:DB8:0:0::1" becomes "2001:db8::1".
+func CanonicalIP() Normalizer {
+	return normalizer{
+		description: "canonical IP addresses",
+		normalize: func(s string) (string, error) {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return "", fmt.Errorf("invalid IP address %q", s)
+			}
+			return ip.String(), nil
+		},
+	}
+}
+
+// Normalize returns a Normalizer that applies each of the normalizers
+// in order, e.g. Normalize(TrimSpace(), CaseFold()).
+func Normalize(normalizers ...Normalizer) Normalizer {
+	descriptions := make([]string, len(normalizers))
+	for i, n := range normalizers {
+		descriptions[i] = n.Description()
+	}
+
+	return normalizer{
+		description: strings.Join(descriptions, ", "),
+		normalize: func(s string) (string, error) {
+			for _, n := range normalizers {
+				var err error
+				if s, err = n.Normalize(s); err != nil {
+					return "", err
+				}
+			}
+			return s, nil
+		},
+	}
+}
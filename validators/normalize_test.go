@@ -0,0 +1,94 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizers(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		normalizer Normalizer
+		input      string
+		expected   string
+		err        string
+	}{
+		{
+			name:       "case fold",
+			normalizer: CaseFold(),
+			input:      "Example.COM",
+			expected:   "example.com",
+		},
+		{
+			name:       "case fold special casing",
+			normalizer: CaseFold(),
+			input:      "Straße",
+			expected:   "strasse",
+		},
+		{
+			name:       "trim",
+			normalizer: TrimSpace(),
+			input:      " \tweb \n",
+			expected:   "web",
+		},
+		{
+			name:       "nfc",
+			normalizer: NFC(),
+			input:      "cafe\u0301",
+			expected:   "caf\u00e9",
+		},
+		{
+			name:       "cidr",
+			normalizer: CanonicalCIDR(),
+			input:      "10.0.0.1/24",
+			expected:   "10.0.0.0/24",
+		},
+		{
+			name:       "cidr ipv6",
+			normalizer: CanonicalCIDR(),
+			input:      "2001:DB8:0:0::1/64",
+			expected:   "2001:db8::/64",
+		},
+		{
+			name:       "cidr without mask",
+			normalizer: CanonicalCIDR(),
+			input:      "10.0.0.1",
+			expected:   "10.0.0.1/32",
+		},
+		{
+			name:       "invalid cidr",
+			normalizer: CanonicalCIDR(),
+			input:      "10.0.0.1/33",
+			err:        "invalid CIDR address: 10.0.0.1/33",
+		},
+		{
+			name:       "ip",
+			normalizer: CanonicalIP(),
+			input:      "2001:DB8:0:0:0:0:0:1",
+			expected:   "2001:db8::1",
+		},
+		{
+			name:       "invalid ip",
+			normalizer: CanonicalIP(),
+			input:      "10.0.0.256",
+			err:        `invalid IP address "10.0.0.256"`,
+		},
+		{
+			name:       "chain",
+			normalizer: Normalize(TrimSpace(), CaseFold()),
+			input:      " Example.COM ",
+			expected:   "example.com",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := test.normalizer.Normalize(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, actual)
+		})
+	}
+}
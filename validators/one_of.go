@@ -0,0 +1,190 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	oneOfErr = "Invalid Attribute Combination"
+)
+
+type oneOfValidator struct {
+	// atLeastOne and atMostOne are the constraints on the number of members that are set.
+	atLeastOne, atMostOne bool
+	members               []string
+}
+
+// ExactlyOneOf ensures that exactly one of the attributes is set.
+//
+// When attached to an attribute, the attributes are path expressions (see
+// ConflictsWith) and the attribute being validated is always a member. When
+// attached to an object or a block, the attributes are resolved relative to
+// it (or to each of its elements), so that "password" names its child.
+//
+// Attributes that are unknown are assumed to possibly be set.
+func ExactlyOneOf(attributes ...string) tfsdk.AttributeValidator {
+	return oneOfValidator{
+		atLeastOne: true,
+		atMostOne:  true,
+		members:    attributes,
+	}
+}
+
+// AtLeastOneOf ensures that at least one of the attributes is set. The
+// attributes are resolved in the same way as ExactlyOneOf.
+func AtLeastOneOf(attributes ...string) tfsdk.AttributeValidator {
+	return oneOfValidator{
+		atLeastOne: true,
+		members:    attributes,
+	}
+}
+
+// AtMostOneOf ensures that no more than one of the attributes is set. The
+// attributes are resolved in the same way as ExactlyOneOf.
+func AtMostOneOf(attributes ...string) tfsdk.AttributeValidator {
+	return oneOfValidator{
+		atMostOne: true,
+		members:   attributes,
+	}
+}
+
+// Description describes this validator.
+func (v oneOfValidator) Description(context.Context) string {
+	return fmt.Sprintf("Ensures that %s %s is set.", v.quantifier(), strings.Join(v.members, ", "))
+}
+
+// MarkdownDescription describes this validator.
+func (v oneOfValidator) MarkdownDescription(context.Context) string {
+	return fmt.Sprintf("Ensures that %s `%s` is set.", v.quantifier(), strings.Join(v.members, "`, `"))
+}
+
+func (v oneOfValidator) quantifier() string {
+	switch {
+	case v.atLeastOne && v.atMostOne:
+		return "exactly one of"
+	case v.atLeastOne:
+		return "at least one of"
+	}
+	return "at most one of"
+}
+
+// Validate performs validation on an attribute.
+func (v oneOfValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			oneOfErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	switch typ := this.Type(); {
+	case typ.Is(tftypes.Object{}):
+		// There's nothing to check until the object is set.
+		if !this.IsKnown() || this.IsNull() {
+			return
+		}
+		resp.Diagnostics.Append(v.validate(ctx, req, req.AttributePath, req.AttributePath, v.members, nil)...)
+	case typ.Is(tftypes.List{}) && typ.(tftypes.List).ElementType.Is(tftypes.Object{}),
+		typ.Is(tftypes.Set{}) && typ.(tftypes.Set).ElementType.Is(tftypes.Object{}):
+		// A block, whose elements can't be checked until they are known.
+		if !this.IsKnown() || this.IsNull() {
+			return
+		}
+
+		var elems []tftypes.Value
+		if err := this.As(&elems); err != nil {
+			resp.Diagnostics.AddError(
+				oneOfErr,
+				"The validator had an internal error: "+err.Error(),
+			)
+			return
+		}
+
+		for i, elem := range elems {
+			if !elem.IsKnown() || elem.IsNull() {
+				continue
+			}
+			path := req.AttributePath.WithElementKeyValue(elem)
+			if typ.Is(tftypes.List{}) {
+				path = req.AttributePath.WithElementKeyInt(i)
+			}
+			resp.Diagnostics.Append(v.validate(ctx, req, path, path, v.members, nil)...)
+		}
+	default:
+		// This attribute is always a member.
+		var name tftypes.AttributeName
+		ok := false
+		if steps := req.AttributePath.Steps(); len(steps) > 0 {
+			name, ok = steps[len(steps)-1].(tftypes.AttributeName)
+		}
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath,
+				oneOfErr,
+				"Unsupported attribute. Only attributes of an object are supported.",
+			)
+			return
+		}
+		resp.Diagnostics.Append(v.validate(ctx, req, req.AttributePath, parentPath(req.AttributePath), append([]string{string(name)}, v.members...), &this)...)
+	}
+}
+
+// validate checks the members, resolved relative to the object at from, and reports
+// any violation at the path at. If this is provided, it is the value of the first member.
+func (v oneOfValidator) validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, at, from *tftypes.AttributePath, members []string, this *tftypes.Value) (diags diag.Diagnostics) {
+	var set, unknown int
+	var names, setNames []string
+	seen := map[string]bool{}
+	for i, member := range members {
+		value, path, ok, d := getAttributeFrom(ctx, req, from, member)
+		diags.Append(d...)
+		if d.HasError() {
+			return
+		}
+
+		// A member may be listed more than once, e.g. the attribute being validated.
+		if seen[path.String()] {
+			continue
+		}
+		seen[path.String()] = true
+		names = append(names, member)
+
+		if i == 0 && this != nil {
+			value, ok = *this, true
+		}
+
+		switch {
+		case !ok || value.IsNull():
+		case !value.IsKnown():
+			unknown++
+		default:
+			set++
+			setNames = append(setNames, member)
+		}
+	}
+
+	quantifier := strings.ToUpper(v.quantifier()[:1]) + v.quantifier()[1:]
+	switch {
+	case v.atLeastOne && set+unknown == 0:
+		diags.AddAttributeError(
+			at,
+			oneOfErr,
+			fmt.Sprintf("%s %s must be set, but none were.", quantifier, strings.Join(names, ", ")),
+		)
+	case v.atMostOne && set > 1:
+		diags.AddAttributeError(
+			at,
+			oneOfErr,
+			fmt.Sprintf("%s %s must be set, but %s were.", quantifier, strings.Join(names, ", "), strings.Join(setNames, ", ")),
+		)
+	}
+	return
+}
@@ -0,0 +1,164 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestOneOf(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("password")
+
+	credentials := func(password, sshKey, certificate types.String) map[string]attr.Value {
+		return map[string]attr.Value{
+			"password":    password,
+			"ssh_key":     sshKey,
+			"certificate": certificate,
+		}
+	}
+	set := types.String{Value: "set"}
+	null := types.String{Null: true}
+	unknown := types.String{Unknown: true}
+
+	for _, test := range []testCase{
+		{
+			name:      "exactly one pass",
+			validator: ExactlyOneOf("ssh_key", "certificate"),
+			request:   testRequest("password", credentials(null, set, null)),
+		},
+		{
+			name:      "exactly one none set",
+			validator: ExactlyOneOf("ssh_key", "certificate"),
+			request:   testRequest("password", credentials(null, null, null)),
+			err:       true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, oneOfErr, "Exactly one of password, ssh_key, certificate must be set, but none were."),
+			},
+		},
+		{
+			name:      "exactly one too many set",
+			validator: ExactlyOneOf("password", "ssh_key", "certificate"),
+			request:   testRequest("password", credentials(set, null, set)),
+			err:       true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, oneOfErr, "Exactly one of password, ssh_key, certificate must be set, but password, certificate were."),
+			},
+		},
+		{
+			name:      "exactly one unknown",
+			validator: ExactlyOneOf("ssh_key", "certificate"),
+			request:   testRequest("password", credentials(null, unknown, null)),
+		},
+		{
+			name:      "at least one pass",
+			validator: AtLeastOneOf("ssh_key", "certificate"),
+			request:   testRequest("password", credentials(set, set, null)),
+		},
+		{
+			name:      "at least one fail",
+			validator: AtLeastOneOf("ssh_key", "certificate"),
+			request:   testRequest("password", credentials(null, null, null)),
+			err:       true,
+		},
+		{
+			name:      "at most one pass",
+			validator: AtMostOneOf("ssh_key", "certificate"),
+			request:   testRequest("password", credentials(null, null, null)),
+		},
+		{
+			name:      "at most one unknown",
+			validator: AtMostOneOf("ssh_key", "certificate"),
+			request:   testRequest("password", credentials(set, unknown, unknown)),
+		},
+		{
+			name:      "at most one fail",
+			validator: AtMostOneOf("ssh_key", "certificate"),
+			request:   testRequest("password", credentials(set, set, null)),
+			err:       true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, oneOfErr, "At most one of password, ssh_key, certificate must be set, but password, ssh_key were."),
+			},
+		},
+		{
+			name:      "missing attribute",
+			validator: AtMostOneOf("api_key"),
+			request:   testRequest("password", credentials(set, null, null)),
+			err:       true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
+
+func TestOneOfBlock(t *testing.T) {
+	authType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"password": types.StringType,
+			"ssh_key":  types.StringType,
+		},
+	}
+	auth := func(password, sshKey types.String) types.Object {
+		return types.Object{
+			AttrTypes: authType.AttrTypes,
+			Attrs: map[string]attr.Value{
+				"password": password,
+				"ssh_key":  sshKey,
+			},
+		}
+	}
+	set := types.String{Value: "set"}
+	null := types.String{Null: true}
+
+	path := tftypes.NewAttributePath().WithAttributeName("auth")
+
+	for _, test := range []testCase{
+		{
+			name:      "object pass",
+			validator: ExactlyOneOf("password", "ssh_key"),
+			request:   testRequest("auth", map[string]attr.Value{"auth": auth(set, null)}),
+		},
+		{
+			name:      "object fail",
+			validator: ExactlyOneOf("password", "ssh_key"),
+			request:   testRequest("auth", map[string]attr.Value{"auth": auth(null, null)}),
+			err:       true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, oneOfErr, "Exactly one of password, ssh_key must be set, but none were."),
+			},
+		},
+		{
+			name:      "object not set",
+			validator: ExactlyOneOf("password", "ssh_key"),
+			request: testRequest("auth", map[string]attr.Value{
+				"auth": types.Object{AttrTypes: authType.AttrTypes, Null: true},
+			}),
+		},
+		{
+			name:      "block",
+			validator: ExactlyOneOf("password", "ssh_key"),
+			request: testRequest("auth", map[string]attr.Value{
+				"auth": types.List{
+					ElemType: authType,
+					Elems:    []attr.Value{auth(set, null), auth(set, set)},
+				},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(1),
+					oneOfErr,
+					"Exactly one of password, ssh_key must be set, but password, ssh_key were.",
+				),
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
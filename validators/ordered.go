@@ -0,0 +1,164 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	orderedErr         = "Out of order elements detected."
+	orderedDescription = "Ensures that the comparison between every element of the list and the next one holds."
+)
+
+type orderedValidator struct {
+	comparator Comparator
+	attribute  string
+}
+
+// Ordered ensures that the comparison between every element of a list of
+// numbers or strings and the element after it holds, where the earlier
+// element is the left operand. For example, ComparatorLessThan requires the
+// elements to be strictly increasing and ComparatorGreaterThanEqual requires
+// them to be non-increasing. Every pair that is out of order is reported.
+// Null elements are skipped, so the elements on either side of one are
+// compared to each other, and nothing past an unknown element is checked.
+func Ordered(comparator Comparator) tfsdk.AttributeValidator {
+	return orderedValidator{
+		comparator: comparator,
+	}
+}
+
+// OrderedBy is the same as Ordered, but for a list of objects
+// that are ordered by the number or string attribute.
+func OrderedBy(attribute string, comparator Comparator) tfsdk.AttributeValidator {
+	return orderedValidator{
+		comparator: comparator,
+		attribute:  attribute,
+	}
+}
+
+// Description describes this validator.
+func (v orderedValidator) Description(context.Context) string {
+	return orderedDescription
+}
+
+// MarkdownDescription describes this validator.
+func (v orderedValidator) MarkdownDescription(context.Context) string {
+	return orderedDescription
+}
+
+// Validate performs validation on an attribute.
+func (v orderedValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	if v.comparator == ComparatorUnknown {
+		resp.Diagnostics.AddError(
+			orderedErr,
+			"Unknown comparator",
+		)
+		return
+	}
+
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			orderedErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	// We don't need to do any validation if the value isn't "set".
+	if !this.IsKnown() || this.IsNull() {
+		return
+	}
+
+	// Sets have no order to speak of.
+	if !this.Type().Is(tftypes.List{}) && !this.Type().Is(tftypes.Tuple{}) {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			orderedErr,
+			"Unsupported type. Only lists are supported.",
+		)
+		return
+	}
+
+	var elems []tftypes.Value
+	if err := this.As(&elems); err != nil {
+		resp.Diagnostics.AddError(
+			orderedErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	// The previous element that is "set", if any.
+	var prev tftypes.Value
+	var prevPath *tftypes.AttributePath
+
+	for i, elem := range elems {
+		path := req.AttributePath.WithElementKeyInt(i)
+
+		value, err := v.orderedValue(elem)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath,
+				orderedErr,
+				err.Error(),
+			)
+			return
+		}
+		if v.attribute != "" {
+			path = path.WithAttributeName(v.attribute)
+		}
+
+		// A null element is skipped, so its neighbors are compared to each other.
+		if value.IsNull() {
+			continue
+		}
+		// The order can't be checked past an element until it is known.
+		if !value.IsKnown() {
+			return
+		}
+
+		if prevPath != nil {
+			if err := compare(prev, value, v.comparator); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path,
+					orderedErr,
+					fmt.Sprintf("%s and %s are out of order: %s.", formatPath(prevPath), formatPath(path), err),
+				)
+			}
+		}
+		prev, prevPath = value, path
+	}
+}
+
+// orderedValue returns the value of the element by which it is ordered.
+func (v orderedValidator) orderedValue(elem tftypes.Value) (tftypes.Value, error) {
+	if v.attribute != "" {
+		if !elem.Type().Is(tftypes.Object{}) {
+			return tftypes.Value{}, errors.New("Unsupported type. Only lists of objects are supported.")
+		}
+		if !elem.IsKnown() || elem.IsNull() {
+			return elem, nil
+		}
+
+		var attrs map[string]tftypes.Value
+		if err := elem.As(&attrs); err != nil {
+			return tftypes.Value{}, errors.New("The validator had an internal error: " + err.Error())
+		}
+		value, ok := attrs[v.attribute]
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("The objects must have the attribute %s.", v.attribute)
+		}
+		elem = value
+	}
+
+	if !elem.Type().Is(tftypes.Number) && !elem.Type().Is(tftypes.String) {
+		return tftypes.Value{}, errors.New("Unsupported type. Only numbers and strings can be ordered.")
+	}
+	return elem, nil
+}
@@ -0,0 +1,187 @@
+package validators
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestOrdered(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("rules")
+
+	priorities := func(values ...types.Number) types.List {
+		objects := make([]map[string]attr.Value, len(values))
+		for i, value := range values {
+			objects[i] = map[string]attr.Value{"priority": value}
+		}
+		return objectList(objects...)
+	}
+	n := func(f float64) types.Number {
+		return types.Number{Value: big.NewFloat(f)}
+	}
+
+	for _, test := range []testCase{
+		{
+			name:      "increasing numbers",
+			validator: Ordered(ComparatorLessThan),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: numberList(1, 2.5, 10),
+			},
+		},
+		{
+			name:      "numbers out of order",
+			validator: Ordered(ComparatorLessThan),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: numberList(1, 1, 10, 5),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(1),
+					orderedErr,
+					"rules[0] and rules[1] are out of order: 1 is not less than 1.",
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(3),
+					orderedErr,
+					"rules[2] and rules[3] are out of order: 10 is not less than 5.",
+				),
+			},
+		},
+		{
+			name:      "non-increasing numbers",
+			validator: Ordered(ComparatorGreaterThanEqual),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: numberList(10, 10, 5),
+			},
+		},
+		{
+			name:      "strings out of order",
+			validator: Ordered(ComparatorLessThan),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("dev", "staging", "prod"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					orderedErr,
+					`rules[1] and rules[2] are out of order: "staging" is not less than "prod".`,
+				),
+			},
+		},
+		{
+			name:      "objects",
+			validator: OrderedBy("priority", ComparatorLessThan),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: priorities(n(100), n(200), n(300)),
+			},
+		},
+		{
+			name:      "objects out of order",
+			validator: OrderedBy("priority", ComparatorLessThan),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: priorities(n(100), n(300), n(200)),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2).WithAttributeName("priority"),
+					orderedErr,
+					"rules[1].priority and rules[2].priority are out of order: 300 is not less than 200.",
+				),
+			},
+		},
+		{
+			name:      "unknown and null elements",
+			validator: OrderedBy("priority", ComparatorLessThan),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: priorities(n(100), types.Number{Unknown: true}, n(50), types.Number{Null: true}, n(10)),
+			},
+		},
+		{
+			name:      "null element",
+			validator: OrderedBy("priority", ComparatorLessThan),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: priorities(n(1), types.Number{Null: true}, n(0)),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2).WithAttributeName("priority"),
+					orderedErr,
+					"rules[0].priority and rules[2].priority are out of order: 1 is not less than 0.",
+				),
+			},
+		},
+		{
+			name:      "nothing is checked past an unknown element",
+			validator: OrderedBy("priority", ComparatorLessThan),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: priorities(n(1), types.Number{Unknown: true}, n(3), n(2)),
+			},
+		},
+		{
+			name:      "missing attribute",
+			validator: OrderedBy("metric", ComparatorLessThan),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: priorities(n(100)),
+			},
+			err: true,
+		},
+		{
+			name:      "bools",
+			validator: Ordered(ComparatorLessThan),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.BoolType,
+					Elems:    []attr.Value{types.Bool{Value: true}},
+				},
+			},
+			err: true,
+		},
+		{
+			name:      "set",
+			validator: Ordered(ComparatorLessThan),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.Set{
+					ElemType: types.NumberType,
+					Elems:    []attr.Value{n(1)},
+				},
+			},
+			err: true,
+		},
+		{
+			name:      "unknown",
+			validator: Ordered(ComparatorLessThan),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.NumberType,
+					Unknown:  true,
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	invalidPathErr = "Invalid Attribute Path"
+)
+
+var (
+	pathSegmentRegex = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_-]*)((?:\[[^\]]*\])*)$`)
+	pathIndexRegex   = regexp.MustCompile(`\[([^\]]*)\]`)
+)
+
+// parsePath returns the path described by expr, relative to the object at
+// from. A path expression is made of segments separated by "/":
+//
+//   - "name" is an attribute of the object.
+//   - "a/b" is the attribute b of the attribute a of the object.
+//   - "../name" is an attribute one level up (.. may be repeated).
+//   - "/name" is an attribute at the root of the configuration.
+//   - "name[0]" and "name[\"key\"]" are an element of a list or map.
+func parsePath(from *tftypes.AttributePath, expr string) (*tftypes.AttributePath, error) {
+	if expr == "" {
+		return nil, errors.New("The path must not be empty.")
+	}
+
+	path := from
+	if path == nil {
+		path = tftypes.NewAttributePath()
+	}
+	if strings.HasPrefix(expr, "/") {
+		path = tftypes.NewAttributePath()
+		expr = expr[1:]
+	}
+
+	for _, segment := range strings.Split(expr, "/") {
+		if segment == ".." {
+			if len(path.Steps()) == 0 {
+				return nil, fmt.Errorf("The path %q goes above the root of the configuration.", expr)
+			}
+			path = parentPath(path)
+			continue
+		}
+
+		matches := pathSegmentRegex.FindStringSubmatch(segment)
+		if matches == nil {
+			return nil, fmt.Errorf("The path %q has an invalid segment %q.", expr, segment)
+		}
+		path = path.WithAttributeName(matches[1])
+
+		for _, index := range pathIndexRegex.FindAllStringSubmatch(matches[2], -1) {
+			if key, err := strconv.Unquote(index[1]); err == nil {
+				path = path.WithElementKeyString(key)
+				continue
+			}
+			i, err := strconv.Atoi(index[1])
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("The path %q has an invalid index [%s].", expr, index[1])
+			}
+			path = path.WithElementKeyInt(i)
+		}
+	}
+
+	return path, nil
+}
+
+// parentPath returns the path of the object that holds the attribute at path,
+// skipping over any list, set or map elements in between.
+func parentPath(path *tftypes.AttributePath) *tftypes.AttributePath {
+	for len(path.Steps()) > 0 {
+		_, isName := path.Steps()[len(path.Steps())-1].(tftypes.AttributeName)
+		path = path.WithoutLastStep()
+		if isName {
+			break
+		}
+	}
+	if path == nil {
+		return tftypes.NewAttributePath()
+	}
+	return path
+}
+
+// getAttribute returns the value of the attribute described by the path
+// expression, which is resolved relative to the attribute being validated
+// (see parsePath). If any of the values that hold the attribute is null or
+// unknown, so is the attribute. ok is false if the attribute does not exist
+// in the configuration, e.g. an element past the end of a list. An error is
+// returned if the path expression is invalid or does not exist in the schema.
+//
+// The value is read from the raw configuration, rather than through
+// req.Config.GetAttribute, so that numbers keep their full precision.
+func getAttribute(ctx context.Context, req tfsdk.ValidateAttributeRequest, expr string) (value tftypes.Value, path *tftypes.AttributePath, ok bool, diags diag.Diagnostics) {
+	return getAttributeFrom(ctx, req, parentPath(req.AttributePath), expr)
+}
+
+// getAttributeFrom is the same as getAttribute, except that the path expression
+// is resolved relative to the object at from, e.g. the attribute being validated
+// rather than the object that holds it.
+func getAttributeFrom(ctx context.Context, req tfsdk.ValidateAttributeRequest, from *tftypes.AttributePath, expr string) (value tftypes.Value, path *tftypes.AttributePath, ok bool, diags diag.Diagnostics) {
+	path, err := parsePath(from, expr)
+	if err != nil {
+		diags.AddAttributeError(req.AttributePath, invalidPathErr, err.Error())
+		return
+	}
+
+	typ, err := req.Config.Schema.AttributeTypeAtPath(path)
+	if err != nil {
+		diags.AddAttributeError(
+			req.AttributePath,
+			invalidPathErr,
+			fmt.Sprintf("The path %q (%s) does not exist in the schema.", expr, formatPath(path)),
+		)
+		return
+	}
+
+	value, err = walkValue(req.Config.Raw, path)
+	if err == nil {
+		return value, path, true, diags
+	}
+
+	// The path can't be walked through a value that isn't set.
+	steps := path.Steps()
+	for i := range steps {
+		parent, err := walkValue(req.Config.Raw, tftypes.NewAttributePathWithSteps(steps[:i]))
+		if err != nil {
+			break
+		}
+		if !parent.IsKnown() {
+			return tftypes.NewValue(typ.TerraformType(ctx), tftypes.UnknownValue), path, true, diags
+		}
+		if parent.IsNull() {
+			return tftypes.NewValue(typ.TerraformType(ctx), nil), path, true, diags
+		}
+	}
+	return value, path, false, diags
+}
+
+// walkValue returns the value at path within root.
+func walkValue(root tftypes.Value, path *tftypes.AttributePath) (tftypes.Value, error) {
+	found, _, err := tftypes.WalkAttributePath(root, path)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	value, ok := found.(tftypes.Value)
+	if !ok {
+		return tftypes.Value{}, fmt.Errorf("unexpected type %T at %s", found, path)
+	}
+	return value, nil
+}
+
+// attributeValue returns the value of the attribute being validated. It is
+// read from the raw configuration where possible, as the framework rounds the
+// numbers in req.AttributeConfig to 53 bits, and from req.AttributeConfig when
+// the raw configuration doesn't hold it, e.g. for a key validated by MapKeys.
+func attributeValue(ctx context.Context, req tfsdk.ValidateAttributeRequest) (tftypes.Value, error) {
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	if req.Config.Raw.Type() == nil || req.AttributePath == nil {
+		return this, nil
+	}
+	raw, err := walkValue(req.Config.Raw, req.AttributePath)
+	if err != nil {
+		return this, nil
+	}
+
+	// The raw value is only used if it's the one the attribute was read from.
+	value, err := req.AttributeConfig.Type(ctx).ValueFromTerraform(ctx, raw)
+	if err != nil || !value.Equal(req.AttributeConfig) {
+		return this, nil
+	}
+	return raw, nil
+}
@@ -0,0 +1,98 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePath(t *testing.T) {
+	root := tftypes.NewAttributePath()
+	nested := root.WithAttributeName("rules").WithElementKeyInt(0).WithAttributeName("autoscaling").WithAttributeName("max")
+
+	for _, test := range []struct {
+		name     string
+		base     *tftypes.AttributePath
+		expr     string
+		expected *tftypes.AttributePath
+		err      bool
+	}{
+		{
+			name:     "sibling",
+			base:     root.WithAttributeName("min"),
+			expr:     "max",
+			expected: root.WithAttributeName("max"),
+		},
+		{
+			name:     "nested sibling",
+			base:     nested,
+			expr:     "min",
+			expected: root.WithAttributeName("rules").WithElementKeyInt(0).WithAttributeName("autoscaling").WithAttributeName("min"),
+		},
+		{
+			name:     "parent",
+			base:     nested,
+			expr:     "../node_count",
+			expected: root.WithAttributeName("rules").WithElementKeyInt(0).WithAttributeName("node_count"),
+		},
+		{
+			name:     "parent skips elements",
+			base:     nested,
+			expr:     "../../node_count",
+			expected: root.WithAttributeName("node_count"),
+		},
+		{
+			name:     "root",
+			base:     nested,
+			expr:     "/limits/max",
+			expected: root.WithAttributeName("limits").WithAttributeName("max"),
+		},
+		{
+			name:     "relative nested",
+			base:     root.WithAttributeName("min"),
+			expr:     "limits/max",
+			expected: root.WithAttributeName("limits").WithAttributeName("max"),
+		},
+		{
+			name:     "indexes",
+			base:     root.WithAttributeName("min"),
+			expr:     `rules[1]/tags["env"]`,
+			expected: root.WithAttributeName("rules").WithElementKeyInt(1).WithAttributeName("tags").WithElementKeyString("env"),
+		},
+		{
+			name: "above root",
+			base: root.WithAttributeName("min"),
+			expr: "../max",
+			err:  true,
+		},
+		{
+			name: "empty",
+			base: root.WithAttributeName("min"),
+			expr: "",
+			err:  true,
+		},
+		{
+			name: "empty segment",
+			base: root.WithAttributeName("min"),
+			expr: "limits//max",
+			err:  true,
+		},
+		{
+			name: "invalid index",
+			base: root.WithAttributeName("min"),
+			expr: "rules[-1]",
+			err:  true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			path, err := parsePath(parentPath(test.base), test.expr)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, test.expected.Equal(path), "expected %s, got %s", test.expected, path)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/frankgreco/terraform-helpers/internal/overlap"
+	"github.com/frankgreco/terraform-helpers/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	portRangeErr         = "Invalid Port Range"
+	portRangeDescription = "Ensures that the value is a port (e.g. \"80\") or an inclusive port range (e.g. \"8000-8080\") between 1 and 65535."
+)
+
+type portRangeValidator struct{}
+
+// PortRange ensures that the value is either a port, e.g. "80", or an inclusive
+// range of ports, e.g. "8000-8080", where every port is between 1 and 65535.
+// Number attributes are also accepted, in which case they must be a single port.
+func PortRange() tfsdk.AttributeValidator {
+	return portRangeValidator{}
+}
+
+// Description describes this validator.
+func (v portRangeValidator) Description(context.Context) string {
+	return portRangeDescription
+}
+
+// MarkdownDescription describes this validator.
+func (v portRangeValidator) MarkdownDescription(context.Context) string {
+	return portRangeDescription
+}
+
+// Validate performs validation on an attribute.
+func (v portRangeValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			portRangeErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	if _, _, err := portPair(this); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			portRangeErr,
+			err.Error(),
+		)
+	}
+}
+
+// portPair returns the range of ports held by value, which must be either
+// a string or a number. ok is false if the value isn't "set".
+func portPair(value tftypes.Value) (pair utils.OrderedPair, ok bool, err error) {
+	if !value.IsKnown() || value.IsNull() {
+		return nil, false, nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var str string
+		if err := value.As(&str); err != nil {
+			return nil, false, errors.New("The validator had an internal error: " + err.Error())
+		}
+		pair, err = overlap.ParsePortRange(str)
+	case value.Type().Is(tftypes.Number):
+		var number big.Float
+		if err := value.As(&number); err != nil {
+			return nil, false, errors.New("The validator had an internal error: " + err.Error())
+		}
+		if !number.IsInt() {
+			return nil, false, errors.New("Invalid port " + formatNumber(&number) + ": must be a whole number")
+		}
+		port, accuracy := number.Int64()
+		if accuracy != big.Exact {
+			return nil, false, fmt.Errorf("Invalid port %s: must be between %d and %d", formatNumber(&number), overlap.MinPort, overlap.MaxPort)
+		}
+		pair, err = overlap.Port(port)
+	default:
+		return nil, false, errors.New("Unsupported type. Only string and number are supported.")
+	}
+
+	return pair, err == nil, err
+}
@@ -0,0 +1,105 @@
+package validators
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPortRange(t *testing.T) {
+	for _, test := range []testCase{
+		{
+			name:      "port",
+			validator: PortRange(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Value: "443"},
+			},
+		},
+		{
+			name:      "range",
+			validator: PortRange(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Value: "8000-8080"},
+			},
+		},
+		{
+			name:      "number",
+			validator: PortRange(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.Number{Value: big.NewFloat(22)},
+			},
+		},
+		{
+			name:      "out of range",
+			validator: PortRange(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Value: "1-65536"},
+			},
+			err: true,
+		},
+		{
+			name:      "reversed",
+			validator: PortRange(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Value: "8080-8000"},
+			},
+			err: true,
+		},
+		{
+			name:      "number out of range",
+			validator: PortRange(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.Number{Value: big.NewFloat(0)},
+			},
+			err: true,
+		},
+		{
+			name:      "number too large for an integer",
+			validator: PortRange(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.Number{Value: big.NewFloat(1e20)},
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(nil, portRangeErr, "Invalid port 100000000000000000000: must be between 1 and 65535"),
+			},
+		},
+		{
+			name:      "fractional number",
+			validator: PortRange(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.Number{Value: big.NewFloat(80.5)},
+			},
+			err: true,
+		},
+		{
+			name:      "null",
+			validator: PortRange(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Null: true},
+			},
+		},
+		{
+			name:      "unknown",
+			validator: PortRange(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.String{Unknown: true},
+			},
+		},
+		{
+			name:      "wrong type",
+			validator: PortRange(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributeConfig: types.Bool{Value: true},
+			},
+			err: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
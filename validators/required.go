@@ -0,0 +1,92 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+const (
+	requiredErr          = "Missing required attribute."
+	requiredDescription  = "Ensures that the attribute is set."
+	forbiddenErr         = "Forbidden attribute."
+	forbiddenDescription = "Ensures that the attribute is not set."
+)
+
+type requiredValidator struct{}
+
+// Required ensures that the attribute is set (not null). It is meant to be used
+// with When, for attributes that are only required under some condition.
+func Required() tfsdk.AttributeValidator {
+	return requiredValidator{}
+}
+
+// Description describes this validator.
+func (v requiredValidator) Description(context.Context) string {
+	return requiredDescription
+}
+
+// MarkdownDescription describes this validator.
+func (v requiredValidator) MarkdownDescription(context.Context) string {
+	return requiredDescription
+}
+
+// Validate performs validation on an attribute.
+func (v requiredValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			requiredErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	if this.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			requiredErr,
+			fmt.Sprintf("%s must be set.", formatPath(req.AttributePath)),
+		)
+	}
+}
+
+type forbiddenValidator struct{}
+
+// Forbidden ensures that the attribute is not set (null). It is meant to be used
+// with When, for attributes that are only forbidden under some condition.
+func Forbidden() tfsdk.AttributeValidator {
+	return forbiddenValidator{}
+}
+
+// Description describes this validator.
+func (v forbiddenValidator) Description(context.Context) string {
+	return forbiddenDescription
+}
+
+// MarkdownDescription describes this validator.
+func (v forbiddenValidator) MarkdownDescription(context.Context) string {
+	return forbiddenDescription
+}
+
+// Validate performs validation on an attribute.
+func (v forbiddenValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			forbiddenErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	// An unknown value may yet turn out to be null.
+	if this.IsKnown() && !this.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			forbiddenErr,
+			fmt.Sprintf("%s must not be set.", formatPath(req.AttributePath)),
+		)
+	}
+}
@@ -0,0 +1,70 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRequired(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("ip_address")
+
+	for _, test := range []testCase{
+		{
+			name:      "required pass",
+			validator: Required(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: types.String{Value: "10.0.0.1"},
+			},
+		},
+		{
+			name:      "required unknown",
+			validator: Required(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: types.String{Unknown: true},
+			},
+		},
+		{
+			name:      "required fail",
+			validator: Required(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: types.String{Null: true},
+			},
+			err: true,
+		},
+		{
+			name:      "forbidden pass",
+			validator: Forbidden(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: types.String{Null: true},
+			},
+		},
+		{
+			name:      "forbidden unknown",
+			validator: Forbidden(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: types.String{Unknown: true},
+			},
+		},
+		{
+			name:      "forbidden fail",
+			validator: Forbidden(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: types.String{Value: "10.0.0.1"},
+			},
+			err: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
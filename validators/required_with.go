@@ -0,0 +1,80 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+const (
+	requiredWithErr         = "There was a missing attribute detected."
+	requiredWithDescription = "Ensures that the specificed attributes are set when this attribute is set."
+)
+
+type requiredWithValidator struct {
+	required []string
+}
+
+// RequiredWith ensures that the specificed attributes are set (not null) when this
+// attribute is set. It is the counterpart of ConflictsWith and takes the same path
+// expressions. Attributes that are unknown are assumed to be set.
+func RequiredWith(attributes ...string) tfsdk.AttributeValidator {
+	return requiredWithValidator{
+		required: attributes,
+	}
+}
+
+// Description describes this validator.
+func (v requiredWithValidator) Description(context.Context) string {
+	return requiredWithDescription
+}
+
+// MarkdownDescription describes this validator.
+func (v requiredWithValidator) MarkdownDescription(context.Context) string {
+	return requiredWithDescription
+}
+
+// Validate performs validation on an attribute.
+func (v requiredWithValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	if len(v.required) == 0 {
+		return
+	}
+
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			requiredWithErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	// We don't need to do any validation if the value isn't "set".
+	if !this.IsFullyKnown() || this.IsNull() {
+		return
+	}
+
+	missing := []string{}
+	for _, required := range v.required {
+		data, _, ok, diags := getAttribute(ctx, req, required)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		// An unknown attribute may yet turn out to be set.
+		if !ok || data.IsNull() {
+			missing = append(missing, required)
+		}
+	}
+
+	if len(missing) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			requiredWithErr,
+			fmt.Sprintf("%s requires %s to also be set.", formatPath(req.AttributePath), strings.Join(missing, ", ")),
+		)
+	}
+}
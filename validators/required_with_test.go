@@ -0,0 +1,93 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRequiredWith(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("tls_cert")
+
+	for _, test := range []testCase{
+		{
+			name:      "pass",
+			validator: RequiredWith("tls_key"),
+			request: testRequest("tls_cert", map[string]attr.Value{
+				"tls_cert": types.String{Value: "cert"},
+				"tls_key":  types.String{Value: "key"},
+			}),
+		},
+		{
+			name:      "every missing attribute is reported",
+			validator: RequiredWith("tls_key", "tls_ca", "tls_port"),
+			request: testRequest("tls_cert", map[string]attr.Value{
+				"tls_cert": types.String{Value: "cert"},
+				"tls_key":  types.String{Null: true},
+				"tls_ca":   types.String{Null: true},
+				"tls_port": types.String{Value: "443"},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, requiredWithErr, "tls_cert requires tls_key, tls_ca to also be set."),
+			},
+		},
+		{
+			name:      "unknown attribute is deferred",
+			validator: RequiredWith("tls_key"),
+			request: testRequest("tls_cert", map[string]attr.Value{
+				"tls_cert": types.String{Value: "cert"},
+				"tls_key":  types.String{Unknown: true},
+			}),
+		},
+		{
+			name:      "not set",
+			validator: RequiredWith("tls_key"),
+			request: testRequest("tls_cert", map[string]attr.Value{
+				"tls_cert": types.String{Null: true},
+				"tls_key":  types.String{Null: true},
+			}),
+		},
+		{
+			name:      "parent not set",
+			validator: RequiredWith("tls/key"),
+			request: testRequest("tls_cert", map[string]attr.Value{
+				"tls_cert": types.String{Value: "cert"},
+				"tls": types.Object{
+					AttrTypes: map[string]attr.Type{"key": types.StringType},
+					Null:      true,
+				},
+			}),
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, requiredWithErr, "tls_cert requires tls/key to also be set."),
+			},
+		},
+		{
+			name:      "parent unknown",
+			validator: RequiredWith("tls/key"),
+			request: testRequest("tls_cert", map[string]attr.Value{
+				"tls_cert": types.String{Value: "cert"},
+				"tls": types.Object{
+					AttrTypes: map[string]attr.Type{"key": types.StringType},
+					Unknown:   true,
+				},
+			}),
+		},
+		{
+			name:      "missing attribute",
+			validator: RequiredWith("tls_secret"),
+			request: testRequest("tls_cert", map[string]attr.Value{
+				"tls_cert": types.String{Value: "cert"},
+			}),
+			err: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	sizeErr         = "Invalid Collection Size"
+	sizeDescription = "Ensures that the list, set or map contains %s elements."
+)
+
+type sizeValidator struct {
+	// min and max are the bounds of the size (inclusive),
+	// where a negative max is unbounded.
+	min, max int
+	err      error
+}
+
+// MinSize ensures that the list, set or map contains at least min elements.
+func MinSize(min int) tfsdk.AttributeValidator {
+	return SizeBetween(min, -1)
+}
+
+// MaxSize ensures that the list, set or map contains at most max elements.
+func MaxSize(max int) tfsdk.AttributeValidator {
+	if max < 0 {
+		return sizeValidator{
+			err: fmt.Errorf("This validator was initialized with an invalid size: %d is negative", max),
+		}
+	}
+	return SizeBetween(0, max)
+}
+
+// ExactSize ensures that the list, set or map contains exactly size elements.
+func ExactSize(size int) tfsdk.AttributeValidator {
+	if size < 0 {
+		return sizeValidator{
+			err: fmt.Errorf("This validator was initialized with an invalid size: %d is negative", size),
+		}
+	}
+	return SizeBetween(size, size)
+}
+
+// SizeBetween ensures that the list, set or map contains between min and max
+// elements (inclusive). A negative max leaves the size unbounded.
+//
+// The size of a list or map is known as soon as the collection itself is known,
+// even if some of its elements are not. Unknown elements of a set may turn out
+// to be equal to each other, or to known elements, once they are known, so for
+// a set the size is only reported as too small if it is too small even if every
+// unknown element is distinct.
+func SizeBetween(min, max int) tfsdk.AttributeValidator {
+	v := sizeValidator{
+		min: min,
+		max: max,
+	}
+	if min < 0 {
+		v.err = fmt.Errorf("This validator was initialized with an invalid size: %d is negative", min)
+	} else if max >= 0 && min > max {
+		v.err = fmt.Errorf("This validator was initialized with an invalid range: %d is greater than %d", min, max)
+	}
+	return v
+}
+
+// Description describes this validator.
+func (v sizeValidator) Description(context.Context) string {
+	return fmt.Sprintf(sizeDescription, v.bounds())
+}
+
+// MarkdownDescription describes this validator.
+func (v sizeValidator) MarkdownDescription(context.Context) string {
+	return fmt.Sprintf(sizeDescription, v.bounds())
+}
+
+// bounds describes the bounds of the size, e.g. "at least 2".
+func (v sizeValidator) bounds() string {
+	switch {
+	case v.max < 0:
+		return fmt.Sprintf("at least %d", v.min)
+	case v.min == v.max:
+		return fmt.Sprintf("exactly %d", v.min)
+	case v.min == 0:
+		return fmt.Sprintf("at most %d", v.max)
+	}
+	return fmt.Sprintf("between %d and %d", v.min, v.max)
+}
+
+// Validate performs validation on an attribute.
+func (v sizeValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	if v.err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			sizeErr,
+			v.err.Error(),
+		)
+		return
+	}
+
+	this, err := toValue(ctx, req.AttributeConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			sizeErr,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	// We don't need to do any validation if the value isn't "set".
+	if !this.IsKnown() || this.IsNull() {
+		return
+	}
+
+	// lower and upper are the bounds of the size once every element is known.
+	var lower, upper int
+	switch typ := this.Type(); {
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}):
+		var elems []tftypes.Value
+		if err := this.As(&elems); err != nil {
+			resp.Diagnostics.AddError(
+				sizeErr,
+				"The validator had an internal error: "+err.Error(),
+			)
+			return
+		}
+
+		lower, upper = len(elems), len(elems)
+		if typ.Is(tftypes.Set{}) {
+			lower = 0
+			for _, elem := range elems {
+				if elem.IsFullyKnown() {
+					lower++
+				}
+			}
+			// Every unknown element might equal a known one,
+			// but there's at least one element either way.
+			if lower == 0 && upper > 0 {
+				lower = 1
+			}
+		}
+	case typ.Is(tftypes.Map{}):
+		var elems map[string]tftypes.Value
+		if err := this.As(&elems); err != nil {
+			resp.Diagnostics.AddError(
+				sizeErr,
+				"The validator had an internal error: "+err.Error(),
+			)
+			return
+		}
+		lower, upper = len(elems), len(elems)
+	default:
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			sizeErr,
+			"Unsupported type. Only lists, sets and maps are supported.",
+		)
+		return
+	}
+
+	var actual string
+	switch {
+	case upper < v.min:
+		actual = fmt.Sprint(upper)
+		if lower != upper {
+			actual = fmt.Sprintf("at most %d", upper)
+		}
+	case v.max >= 0 && lower > v.max:
+		actual = fmt.Sprint(lower)
+		if lower != upper {
+			actual = fmt.Sprintf("at least %d", lower)
+		}
+	default:
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.AttributePath,
+		sizeErr,
+		fmt.Sprintf("%s must contain %s element(s), but contains %s.", formatPath(req.AttributePath), v.bounds(), actual),
+	)
+}
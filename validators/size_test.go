@@ -0,0 +1,215 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSize(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("zones")
+
+	known := types.String{Value: "a"}
+	unknown := types.String{Unknown: true}
+
+	set := func(elems ...attr.Value) types.Set {
+		return types.Set{
+			ElemType: types.StringType,
+			Elems:    elems,
+		}
+	}
+	tags := func(n int) types.Map {
+		elems := map[string]attr.Value{}
+		for i := 0; i < n; i++ {
+			elems[string(rune('a'+i))] = types.String{Value: "x"}
+		}
+		return types.Map{
+			ElemType: types.StringType,
+			Elems:    elems,
+		}
+	}
+
+	for _, test := range []testCase{
+		{
+			name:      "min pass",
+			validator: MinSize(2),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("a", "b"),
+			},
+		},
+		{
+			name:      "min fail",
+			validator: MinSize(2),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("a"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					sizeErr,
+					"zones must contain at least 2 element(s), but contains 1.",
+				),
+			},
+		},
+		{
+			name:      "max fail",
+			validator: MaxSize(2),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: tags(3),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					sizeErr,
+					"zones must contain at most 2 element(s), but contains 3.",
+				),
+			},
+		},
+		{
+			name:      "exact fail",
+			validator: ExactSize(2),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("a", "b", "c"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					sizeErr,
+					"zones must contain exactly 2 element(s), but contains 3.",
+				),
+			},
+		},
+		{
+			name:      "between pass",
+			validator: SizeBetween(1, 3),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: tags(3),
+			},
+		},
+		{
+			name:      "list with unknown elements",
+			validator: MaxSize(1),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.StringType,
+					Elems:    []attr.Value{known, unknown},
+				},
+			},
+			err: true,
+		},
+		{
+			name:      "set with unknown elements might be too small",
+			validator: MinSize(2),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: set(known, unknown),
+			},
+		},
+		{
+			name:      "set with unknown elements is too small",
+			validator: MinSize(3),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: set(known, unknown),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					sizeErr,
+					"zones must contain at least 3 element(s), but contains at most 2.",
+				),
+			},
+		},
+		{
+			name:      "set with unknown elements might be too large",
+			validator: MaxSize(1),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: set(known, unknown),
+			},
+		},
+		{
+			name:      "set with unknown elements is too large",
+			validator: MaxSize(1),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: set(known, types.String{Value: "b"}, unknown),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path,
+					sizeErr,
+					"zones must contain at most 1 element(s), but contains at least 2.",
+				),
+			},
+		},
+		{
+			name:      "set of only unknown elements is not empty",
+			validator: MaxSize(0),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: set(unknown),
+			},
+			err: true,
+		},
+		{
+			name:      "unknown",
+			validator: MinSize(1),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.StringType,
+					Unknown:  true,
+				},
+			},
+		},
+		{
+			name:      "null",
+			validator: MinSize(1),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.Map{
+					ElemType: types.StringType,
+					Null:     true,
+				},
+			},
+		},
+		{
+			name:      "string",
+			validator: MinSize(1),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: types.String{Value: "a"},
+			},
+			err: true,
+		},
+		{
+			name:      "invalid range",
+			validator: SizeBetween(3, 2),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("a"),
+			},
+			err: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
@@ -2,10 +2,13 @@ package validators
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,6 +17,9 @@ type testCase struct {
 	validator tfsdk.AttributeValidator
 	request   tfsdk.ValidateAttributeRequest
 	err       bool
+
+	// diagnostics, if set, must match the diagnostics of the response exactly.
+	diagnostics diag.Diagnostics
 }
 
 func (tc testCase) run(t *testing.T) {
@@ -29,5 +35,62 @@ func (tc testCase) run(t *testing.T) {
 		assert.False(t, hasError, "diagnostic contained an unexpected error")
 	}
 
-	// TODO: assert the acutal diagnostics
+	if tc.diagnostics != nil {
+		assert.Equal(t, tc.diagnostics, response.Diagnostics, "diagnostics did not match")
+	}
+}
+
+// testConfig returns a configuration whose schema consists of an
+// optional attribute for each of the provided values.
+func testConfig(values map[string]attr.Value) tfsdk.Config {
+	ctx := context.Background()
+
+	attributes := map[string]tfsdk.Attribute{}
+	attributeTypes := map[string]tftypes.Type{}
+	raw := map[string]tftypes.Value{}
+
+	for name, value := range values {
+		attributes[name] = tfsdk.Attribute{
+			Type:     value.Type(ctx),
+			Optional: true,
+		}
+
+		tfValue, err := toValue(ctx, value)
+		if err != nil {
+			panic(err)
+		}
+		attributeTypes[name] = tfValue.Type()
+		raw[name] = tfValue
+	}
+
+	return tfsdk.Config{
+		Schema: tfsdk.Schema{
+			Attributes: attributes,
+		},
+		Raw: tftypes.NewValue(tftypes.Object{AttributeTypes: attributeTypes}, raw),
+	}
+}
+
+// testRequest returns a request to validate the named attribute of the
+// configuration built from the provided values.
+func testRequest(name string, values map[string]attr.Value) tfsdk.ValidateAttributeRequest {
+	return tfsdk.ValidateAttributeRequest{
+		AttributePath:   tftypes.NewAttributePath().WithAttributeName(name),
+		AttributeConfig: values[name],
+		Config:          testConfig(values),
+	}
+}
+
+// testFrameworkRequest is the same as testRequest, except that the value of
+// the attribute is read from the configuration the way the framework reads
+// it, e.g. with numbers rounded to 53 bits.
+func testFrameworkRequest(name string, values map[string]attr.Value) tfsdk.ValidateAttributeRequest {
+	req := testRequest(name, values)
+
+	value := reflect.New(reflect.TypeOf(values[name]))
+	if diags := req.Config.GetAttribute(context.Background(), req.AttributePath, value.Interface()); diags.HasError() {
+		panic(diags)
+	}
+	req.AttributeConfig = value.Elem().Interface().(attr.Value)
+	return req
 }
@@ -2,10 +2,12 @@ package validators
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 const (
@@ -13,87 +15,223 @@ const (
 )
 
 type uniqueValidator struct {
-	key string
+	keys      []string
+	normalize Normalizer
 }
 
 // Unique returns an tfsdk.AttributeValidator that ensures
-// all elements within a list or set are unique on the provided
-// object attribute.
-func Unique(key string) tfsdk.AttributeValidator {
+// all elements within a list, set or map are unique on the provided
+// object attributes. When more than one attribute is provided,
+// the combination of their values is the key, e.g.
+// Unique("protocol", "port", "cidr"). When none are provided, the
+// elements themselves must be unique, e.g. for a list(string).
+//
+// Values of any type are compared exactly. Elements whose key is
+// unknown are not compared until it is known, and elements whose
+// key is null (or has a null part) are not compared at all.
+func Unique(keys ...string) tfsdk.AttributeValidator {
 	return uniqueValidator{
-		key: key,
+		keys: keys,
+	}
+}
+
+// UniqueNormalized is the same as Unique, except that strings are normalized
+// before they are compared, e.g. UniqueNormalized(CaseFold(), "domain") treats
+// "Example.COM" and "example.com" as duplicates. Strings that can't be
+// normalized, e.g. an invalid CIDR, are compared as they are.
+func UniqueNormalized(normalize Normalizer, keys ...string) tfsdk.AttributeValidator {
+	return uniqueValidator{
+		keys:      keys,
+		normalize: normalize,
 	}
 }
 
 func (u uniqueValidator) Description(context.Context) string {
-	return fmt.Sprintf(uniqueErr, u.key)
+	if u.normalize != nil {
+		return fmt.Sprintf("%s Strings are compared once %s.", u.summary(), u.normalize.Description())
+	}
+	return u.summary()
+}
+
+func (u uniqueValidator) MarkdownDescription(ctx context.Context) string {
+	return u.Description(ctx)
 }
 
-func (u uniqueValidator) MarkdownDescription(context.Context) string {
-	return fmt.Sprintf(uniqueErr, u.key)
+func (u uniqueValidator) summary() string {
+	if len(u.keys) == 0 {
+		return "Unique constraint was violated."
+	}
+	return fmt.Sprintf(uniqueErr, strings.Join(u.keys, ", "))
 }
 
 func (u uniqueValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
-	var items []types.Object
+	summary := u.summary()
+
+	this, err := attributeValue(ctx, req)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			summary,
+			"The validator had an internal error: "+err.Error(),
+		)
+		return
+	}
+
+	// We don't need to do any validation if the value isn't "set".
+	if !this.IsKnown() || this.IsNull() {
+		return
+	}
 
-	diags := tfsdk.ValueAs(ctx, req.AttributeConfig, &items)
-	resp.Diagnostics.Append(diags...)
-	if diags.HasError() {
+	items, paths, err := elements(this, req.AttributePath)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			summary,
+			err.Error(),
+		)
 		return
 	}
 
-	log := map[interface{}]bool{}
+	type first struct {
+		values []tftypes.Value
+		path   *tftypes.AttributePath
+	}
+	// The original values and path of the first item with each key.
+	log := map[string]first{}
 
-	for _, item := range items {
-		if item.Unknown || item.Null {
+	for i, item := range items {
+		values, err := u.values(item)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath,
+				summary,
+				err.Error(),
+			)
+			return
+		}
+
+		// The key can't be compared until every part of it is "set".
+		if !isSet(values) {
 			continue
 		}
 
-		v, ok := item.Attrs[u.key]
+		normalized := u.normalized(values)
+		k := uniqueKey(normalized)
+		f, ok := log[k]
 		if !ok {
-			continue // we'll rely on terraform enforcing `Required: true`
+			log[k] = first{values: values, path: paths[i]}
+			continue
+		}
+
+		detail := fmt.Sprintf("%s duplicates %s, as both have %s", formatPath(paths[i]), formatPath(f.path), u.format(normalized))
+		if written := formatTuple(f.values); written != formatTuple(values) || written != formatTuple(normalized) {
+			detail += fmt.Sprintf(" (written as %s and %s)", written, formatTuple(values))
 		}
+		resp.Diagnostics.AddAttributeError(
+			paths[i],
+			summary,
+			detail+".",
+		)
+	}
+}
+
+// values returns the values of the keys of the item, or the item
+// itself if there are no keys. It returns nil if the item isn't "set".
+func (u uniqueValidator) values(item tftypes.Value) ([]tftypes.Value, error) {
+	if len(u.keys) == 0 {
+		return []tftypes.Value{item}, nil
+	}
+
+	if !item.Type().Is(tftypes.Object{}) {
+		return nil, errors.New("Unsupported type. Only objects have attributes to be unique on.")
+	}
+	if !item.IsKnown() || item.IsNull() {
+		return nil, nil
+	}
+
+	var attrs map[string]tftypes.Value
+	if err := item.As(&attrs); err != nil {
+		return nil, errors.New("The validator had an internal error: " + err.Error())
+	}
 
-		t, ok := item.AttrTypes[u.key]
+	values := make([]tftypes.Value, len(u.keys))
+	for i, key := range u.keys {
+		v, ok := attrs[key]
 		if !ok {
-			resp.Diagnostics.AddAttributeError(
-				req.AttributePath,
-				"Internal Error with Terraform.",
-				"There exists an attribute for which Terraform has not told us the type.",
-			)
-			return
+			return nil, fmt.Errorf("The objects must have the attribute %s.", key)
 		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// normalized returns the values with every string normalized.
+func (u uniqueValidator) normalized(values []tftypes.Value) []tftypes.Value {
+	if u.normalize == nil {
+		return values
+	}
+
+	normalized := make([]tftypes.Value, len(values))
+	for i, value := range values {
+		normalized[i] = value
 
-		var tmp interface{}
-
-		switch t {
-		case types.NumberType:
-			var val int
-			diags := tfsdk.ValueAs(ctx, v, &val)
-			resp.Diagnostics.Append(diags...)
-			if diags.HasError() {
-				return
-			}
-			tmp = val
-		case types.StringType:
-			var val string
-			diags := tfsdk.ValueAs(ctx, v, &val)
-			resp.Diagnostics.Append(diags...)
-			if diags.HasError() {
-				return
-			}
-			tmp = val
+		if !value.Type().Is(tftypes.String) {
+			continue
+		}
+		var str string
+		if err := value.As(&str); err != nil {
+			continue
+		}
+		if str, err := u.normalize.Normalize(str); err == nil {
+			normalized[i] = tftypes.NewValue(tftypes.String, str)
 		}
+	}
+	return normalized
+}
 
-		if _, ok := log[tmp]; ok {
-			resp.Diagnostics.AddAttributeError(
-				req.AttributePath,
-				fmt.Sprintf(uniqueErr, u.key),
-				fmt.Sprintf("More than one item exists with %s=%v", u.key, tmp),
-			)
-			return
+// isSet reports whether every value is fully known and not null.
+func isSet(values []tftypes.Value) bool {
+	if values == nil {
+		return false
+	}
+	for _, value := range values {
+		if !value.IsFullyKnown() || value.IsNull() {
+			return false
 		}
+	}
+	return true
+}
+
+// format describes the values of the keys, e.g. port=443,
+// (protocol, port)=("tcp", 443) or the value "a".
+func (u uniqueValidator) format(values []tftypes.Value) string {
+	switch len(u.keys) {
+	case 0:
+		return "the value " + formatTuple(values)
+	case 1:
+		return u.keys[0] + "=" + formatTuple(values)
+	}
+	return "(" + strings.Join(u.keys, ", ") + ")=" + formatTuple(values)
+}
+
+// formatTuple describes the values, e.g. 443 or ("tcp", 443).
+func formatTuple(values []tftypes.Value) string {
+	if len(values) == 1 {
+		return formatValue(values[0])
+	}
+
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = formatValue(value)
+	}
+	return "(" + strings.Join(formatted, ", ") + ")"
+}
 
-		log[tmp] = true
+// uniqueKey returns a string that is the same for two
+// tuples of values if, and only if, they are equal.
+func uniqueKey(values []tftypes.Value) string {
+	keys := make([]string, len(values))
+	for i, value := range values {
+		keys[i] = valueKey(value)
 	}
+	return strings.Join(keys, ", ")
 }
@@ -0,0 +1,440 @@
+package validators
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUnique(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("rules")
+
+	exactNumber := func(s string) *big.Float {
+		f, _, _ := big.ParseFloat(s, 10, 512, big.ToNearestEven)
+		return f
+	}
+
+	rule := func(protocol string, port float64, cidr string) map[string]attr.Value {
+		return map[string]attr.Value{
+			"protocol": types.String{Value: protocol},
+			"port":     types.Number{Value: big.NewFloat(port)},
+			"cidr":     types.String{Value: cidr},
+			"enabled":  types.Bool{Value: true},
+		}
+	}
+
+	for _, test := range []testCase{
+		{
+			name:      "single key",
+			validator: Unique("port"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					rule("tcp", 80, "10.0.0.0/24"),
+					rule("tcp", 443, "10.0.0.0/24"),
+				),
+			},
+		},
+		{
+			name:      "single key duplicate",
+			validator: Unique("port"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					rule("tcp", 443, "10.0.0.0/24"),
+					rule("udp", 443, "10.0.1.0/24"),
+				),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(1),
+					"Unique constraint was violated for attribute port.",
+					`rules[1] duplicates rules[0], as both have port=443.`,
+				),
+			},
+		},
+		{
+			name:      "composite key",
+			validator: Unique("protocol", "port", "cidr"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					rule("tcp", 443, "10.0.0.0/24"),
+					rule("udp", 443, "10.0.0.0/24"),
+					rule("tcp", 443, "10.0.1.0/24"),
+				),
+			},
+		},
+		{
+			name:      "composite key duplicate",
+			validator: Unique("protocol", "port", "cidr"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					rule("tcp", 443, "10.0.0.0/24"),
+					rule("udp", 443, "10.0.0.0/24"),
+					rule("tcp", 443, "10.0.0.0/24"),
+				),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					"Unique constraint was violated for attribute protocol, port, cidr.",
+					`rules[2] duplicates rules[0], as both have (protocol, port, cidr)=("tcp", 443, "10.0.0.0/24").`,
+				),
+			},
+		},
+		{
+			name:      "bool component",
+			validator: Unique("enabled", "port"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					rule("tcp", 443, "10.0.0.0/24"),
+					rule("udp", 443, "10.0.1.0/24"),
+				),
+			},
+			err: true,
+		},
+		{
+			name:      "fractional numbers",
+			validator: Unique("port"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					rule("tcp", 1.2, "10.0.0.0/24"),
+					rule("tcp", 1.7, "10.0.0.0/24"),
+				),
+			},
+		},
+		{
+			name:      "unknown component",
+			validator: Unique("protocol", "port"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					rule("tcp", 443, "10.0.0.0/24"),
+					map[string]attr.Value{
+						"protocol": types.String{Unknown: true},
+						"port":     types.Number{Value: big.NewFloat(443)},
+						"cidr":     types.String{Value: "10.0.0.0/24"},
+						"enabled":  types.Bool{Value: true},
+					},
+				),
+			},
+		},
+		{
+			name:      "null component",
+			validator: Unique("protocol", "port"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{
+						"protocol": types.String{Null: true},
+						"port":     types.Number{Value: big.NewFloat(443)},
+					},
+					map[string]attr.Value{
+						"protocol": types.String{Null: true},
+						"port":     types.Number{Value: big.NewFloat(443)},
+					},
+				),
+			},
+		},
+		{
+			name:      "list key",
+			validator: Unique("zones"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"zones": stringList("a", "b")},
+					map[string]attr.Value{"zones": stringList("b", "a")},
+					map[string]attr.Value{"zones": stringList("a", "b")},
+				),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					"Unique constraint was violated for attribute zones.",
+					`rules[2] duplicates rules[0], as both have zones=["a", "b"].`,
+				),
+			},
+		},
+		{
+			name:      "partially unknown list key",
+			validator: Unique("zones"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"zones": stringList("a", "b")},
+					map[string]attr.Value{"zones": types.List{
+						ElemType: types.StringType,
+						Elems:    []attr.Value{types.String{Value: "a"}, types.String{Unknown: true}},
+					}},
+				),
+			},
+		},
+		{
+			name:      "object key",
+			validator: Unique("target"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"target": types.Object{
+						AttrTypes: map[string]attr.Type{"port": types.NumberType},
+						Attrs:     map[string]attr.Value{"port": types.Number{Value: big.NewFloat(80)}},
+					}},
+					map[string]attr.Value{"target": types.Object{
+						AttrTypes: map[string]attr.Type{"port": types.NumberType},
+						Attrs:     map[string]attr.Value{"port": types.Number{Value: big.NewFloat(80)}},
+					}},
+				),
+			},
+			err: true,
+		},
+		{
+			name:      "strings",
+			validator: Unique(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("a", "b", "c"),
+			},
+		},
+		{
+			name:      "duplicate strings",
+			validator: Unique(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("a", "b", "a"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					"Unique constraint was violated.",
+					`rules[2] duplicates rules[0], as both have the value "a".`,
+				),
+			},
+		},
+		{
+			name:      "several duplicates",
+			validator: Unique(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("a", "b", "a", "b", "a"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					"Unique constraint was violated.",
+					`rules[2] duplicates rules[0], as both have the value "a".`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(3),
+					"Unique constraint was violated.",
+					`rules[3] duplicates rules[1], as both have the value "b".`,
+				),
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(4),
+					"Unique constraint was violated.",
+					`rules[4] duplicates rules[0], as both have the value "a".`,
+				),
+			},
+		},
+		{
+			name:      "numbers",
+			validator: Unique(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: numberList(1.2, 1.7, 0.1),
+			},
+		},
+		{
+			name:      "duplicate exact numbers",
+			validator: Unique(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.NumberType,
+					Elems: []attr.Value{
+						types.Number{Value: exactNumber("0.1")},
+						types.Number{Value: exactNumber("0.10000000000000000001")},
+						types.Number{Value: exactNumber("0.10")},
+					},
+				},
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					"Unique constraint was violated.",
+					`rules[2] duplicates rules[0], as both have the value 0.1.`,
+				),
+			},
+		},
+		{
+			name:      "numbers read by the framework are compared exactly",
+			validator: Unique(),
+			request: testFrameworkRequest("rules", map[string]attr.Value{
+				"rules": types.List{
+					ElemType: types.NumberType,
+					Elems: []attr.Value{
+						types.Number{Value: exactNumber("9007199254740992")},
+						types.Number{Value: exactNumber("9007199254740993")},
+					},
+				},
+			}),
+		},
+		{
+			name:      "unknown strings",
+			validator: Unique(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.List{
+					ElemType: types.StringType,
+					Elems:    []attr.Value{types.String{Unknown: true}, types.String{Unknown: true}},
+				},
+			},
+		},
+		{
+			name:      "set",
+			validator: Unique(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.Set{
+					ElemType: types.BoolType,
+					Elems:    []attr.Value{types.Bool{Value: true}, types.Bool{Value: false}},
+				},
+			},
+		},
+		{
+			name:      "duplicate map values",
+			validator: Unique(),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.Map{
+					ElemType: types.StringType,
+					Elems: map[string]attr.Value{
+						"a": types.String{Value: "x"},
+						"b": types.String{Value: "y"},
+						"c": types.String{Value: "x"},
+					},
+				},
+			},
+			err: true,
+		},
+		{
+			name:      "map of objects",
+			validator: Unique("port"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: types.Map{
+					ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{"port": types.NumberType}},
+					Elems: map[string]attr.Value{
+						"http": types.Object{
+							AttrTypes: map[string]attr.Type{"port": types.NumberType},
+							Attrs:     map[string]attr.Value{"port": types.Number{Value: big.NewFloat(80)}},
+						},
+						"https": types.Object{
+							AttrTypes: map[string]attr.Type{"port": types.NumberType},
+							Attrs:     map[string]attr.Value{"port": types.Number{Value: big.NewFloat(443)}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "normalized duplicate",
+			validator: UniqueNormalized(Normalize(TrimSpace(), CaseFold()), "domain"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"domain": types.String{Value: "Example.COM"}},
+					map[string]attr.Value{"domain": types.String{Value: "example.org"}},
+					map[string]attr.Value{"domain": types.String{Value: " example.com"}},
+				),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(2),
+					"Unique constraint was violated for attribute domain.",
+					`rules[2] duplicates rules[0], as both have domain="example.com" (written as "Example.COM" and " example.com").`,
+				),
+			},
+		},
+		{
+			name:      "normalized composite key",
+			validator: UniqueNormalized(CanonicalCIDR(), "protocol", "cidr"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath: path,
+				AttributeConfig: objectList(
+					map[string]attr.Value{"protocol": types.String{Value: "tcp"}, "cidr": types.String{Value: "10.0.0.0/24"}},
+					map[string]attr.Value{"protocol": types.String{Value: "tcp"}, "cidr": types.String{Value: "10.0.0.1/24"}},
+				),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(1),
+					"Unique constraint was violated for attribute protocol, cidr.",
+					`rules[1] duplicates rules[0], as both have (protocol, cidr)=("tcp", "10.0.0.0/24") (written as ("tcp", "10.0.0.0/24") and ("tcp", "10.0.0.1/24")).`,
+				),
+			},
+		},
+		{
+			name:      "normalized strings",
+			validator: UniqueNormalized(CanonicalIP()),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("2001:db8::1", "2001:DB8:0:0:0:0:0:1"),
+			},
+			err: true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.WithElementKeyInt(1),
+					"Unique constraint was violated.",
+					`rules[1] duplicates rules[0], as both have the value "2001:db8::1" (written as "2001:db8::1" and "2001:DB8:0:0:0:0:0:1").`,
+				),
+			},
+		},
+		{
+			name:      "strings that can't be normalized",
+			validator: UniqueNormalized(CanonicalCIDR()),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("not a cidr", "Not a CIDR"),
+			},
+		},
+		{
+			name:      "keys of strings",
+			validator: Unique("port"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: stringList("a"),
+			},
+			err: true,
+		},
+		{
+			name:      "missing attribute",
+			validator: Unique("protocol", "name"),
+			request: tfsdk.ValidateAttributeRequest{
+				AttributePath:   path,
+				AttributeConfig: objectList(rule("tcp", 443, "10.0.0.0/24")),
+			},
+			err: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
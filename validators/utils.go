@@ -2,6 +2,11 @@ package validators
 
 import (
 	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -14,3 +19,175 @@ func toValue(ctx context.Context, in attr.Value) (tftypes.Value, error) {
 	}
 	return tftypes.NewValue(in.Type(ctx).TerraformType(ctx), data), nil
 }
+
+// stringElements returns every string held by value, which must be either a
+// string or a list or set of strings, along with the path of each. Null
+// strings are omitted. known is false if any part of the value is unknown.
+func stringElements(value tftypes.Value, path *tftypes.AttributePath) (strs []string, paths []*tftypes.AttributePath, known bool, err error) {
+	if !value.IsFullyKnown() {
+		return nil, nil, false, nil
+	}
+	if value.IsNull() {
+		return nil, nil, true, nil
+	}
+
+	switch typ := value.Type(); {
+	case typ.Is(tftypes.String):
+		var str string
+		if err := value.As(&str); err != nil {
+			return nil, nil, false, err
+		}
+		return []string{str}, []*tftypes.AttributePath{path}, true, nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}):
+		var elems []tftypes.Value
+		if err := value.As(&elems); err != nil {
+			return nil, nil, false, err
+		}
+		for i, elem := range elems {
+			if !elem.Type().Is(tftypes.String) {
+				return nil, nil, false, fmt.Errorf("unsupported element type %s, only strings are supported", elem.Type())
+			}
+			if elem.IsNull() {
+				continue
+			}
+
+			var str string
+			if err := elem.As(&str); err != nil {
+				return nil, nil, false, err
+			}
+			strs = append(strs, str)
+			if typ.Is(tftypes.List{}) {
+				paths = append(paths, path.WithElementKeyInt(i))
+			} else {
+				paths = append(paths, path.WithElementKeyValue(elem))
+			}
+		}
+		return strs, paths, true, nil
+	}
+	return nil, nil, false, fmt.Errorf("unsupported type %s, only strings and lists or sets of strings are supported", value.Type())
+}
+
+// formatPath returns the path in the form a user would write it
+// in their configuration, e.g. rules[0].cidrs[3].
+func formatPath(path *tftypes.AttributePath) string {
+	var res strings.Builder
+	for _, step := range path.Steps() {
+		switch v := step.(type) {
+		case tftypes.AttributeName:
+			if res.Len() > 0 {
+				res.WriteString(".")
+			}
+			res.WriteString(string(v))
+		case tftypes.ElementKeyString:
+			res.WriteString("[" + strconv.Quote(string(v)) + "]")
+		case tftypes.ElementKeyInt:
+			res.WriteString("[" + strconv.FormatInt(int64(v), 10) + "]")
+		case tftypes.ElementKeyValue:
+			res.WriteString("[" + formatValue(tftypes.Value(v)) + "]")
+		}
+	}
+	return res.String()
+}
+
+// formatValue returns the value in the form a user would write it
+// in their configuration.
+func formatValue(value tftypes.Value) string {
+	if !value.IsKnown() {
+		return "(known after apply)"
+	}
+	if value.IsNull() {
+		return "null"
+	}
+
+	switch typ := value.Type(); {
+	case typ.Is(tftypes.String):
+		var str string
+		_ = value.As(&str)
+		return strconv.Quote(str)
+	case typ.Is(tftypes.Number):
+		var number big.Float
+		_ = value.As(&number)
+		return formatNumber(&number)
+	case typ.Is(tftypes.Bool):
+		var b bool
+		_ = value.As(&b)
+		return strconv.FormatBool(b)
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		_ = value.As(&elems)
+		formatted := make([]string, len(elems))
+		for i, elem := range elems {
+			formatted[i] = formatValue(elem)
+		}
+		return "[" + strings.Join(formatted, ", ") + "]"
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var attrs map[string]tftypes.Value
+		_ = value.As(&attrs)
+		keys := make([]string, 0, len(attrs))
+		for key := range attrs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		formatted := make([]string, len(keys))
+		for i, key := range keys {
+			formatted[i] = key + " = " + formatValue(attrs[key])
+		}
+		return "{" + strings.Join(formatted, ", ") + "}"
+	}
+	return fmt.Sprint(value)
+}
+
+// formatNumber returns the shortest decimal representation of the number
+// that does not lose precision.
+func formatNumber(number *big.Float) string {
+	if number.IsInt() {
+		return number.Text('f', 0)
+	}
+	return number.Text('g', -1)
+}
+
+// valueKey returns a string that is the same for two values if, and only if,
+// the values are equal. Unlike tftypes.Value.Equal, numbers are compared
+// exactly. The value must be known.
+func valueKey(value tftypes.Value) string {
+	if value.IsNull() {
+		return "null"
+	}
+
+	switch typ := value.Type(); {
+	case typ.Is(tftypes.Number):
+		var number big.Float
+		_ = value.As(&number)
+		if number.IsInf() {
+			return number.String()
+		}
+		r, _ := number.Rat(nil)
+		return r.RatString()
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		_ = value.As(&elems)
+		keys := make([]string, len(elems))
+		for i, elem := range elems {
+			keys[i] = valueKey(elem)
+		}
+		// The order of the elements of a set doesn't matter.
+		if typ.Is(tftypes.Set{}) {
+			sort.Strings(keys)
+		}
+		return "[" + strings.Join(keys, ", ") + "]"
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var attrs map[string]tftypes.Value
+		_ = value.As(&attrs)
+		keys := make([]string, 0, len(attrs))
+		for key := range attrs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		formatted := make([]string, len(keys))
+		for i, key := range keys {
+			formatted[i] = strconv.Quote(key) + " = " + valueKey(attrs[key])
+		}
+		return "{" + strings.Join(formatted, ", ") + "}"
+	}
+	return formatValue(value)
+}
@@ -0,0 +1,267 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Predicate is a condition on the value of an attribute.
+type Predicate interface {
+	// Description describes the condition, e.g. `= "static"`.
+	Description() string
+
+	// Matches reports whether the value satisfies the condition. The value is
+	// known, but may be null or contain unknown elements, in which case known
+	// is false if the condition can't be decided until they are known. An error
+	// is returned if the predicate itself is invalid.
+	Matches(value tftypes.Value) (matches, known bool, err error)
+}
+
+type whenValidator struct {
+	attribute  string
+	predicate  Predicate
+	validators []tfsdk.AttributeValidator
+}
+
+// When runs the validators against this attribute only when the value of the other
+// attribute satisfies the predicate, e.g. When("type", Equals("static"), Required()).
+// The attribute is a path expression (see ConflictsWith). Nothing is validated while
+// that attribute is unknown, or while it is too unknown for the predicate to decide.
+func When(attribute string, predicate Predicate, validators ...tfsdk.AttributeValidator) tfsdk.AttributeValidator {
+	return whenValidator{
+		attribute:  attribute,
+		predicate:  predicate,
+		validators: validators,
+	}
+}
+
+// Description describes this validator.
+func (v whenValidator) Description(ctx context.Context) string {
+	descriptions := make([]string, len(v.validators))
+	for i, validator := range v.validators {
+		descriptions[i] = validator.Description(ctx)
+	}
+	return fmt.Sprintf("When %s %s: %s", v.attribute, v.predicate.Description(), strings.Join(descriptions, " "))
+}
+
+// MarkdownDescription describes this validator.
+func (v whenValidator) MarkdownDescription(ctx context.Context) string {
+	descriptions := make([]string, len(v.validators))
+	for i, validator := range v.validators {
+		descriptions[i] = validator.MarkdownDescription(ctx)
+	}
+	return fmt.Sprintf("When `%s` %s: %s", v.attribute, v.predicate.Description(), strings.Join(descriptions, " "))
+}
+
+// Validate performs validation on an attribute.
+func (v whenValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	value, _, ok, diags := getAttribute(ctx, req, v.attribute)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	// An attribute that isn't in the configuration, e.g. an element
+	// past the end of a list, is treated as null.
+	if !ok {
+		value = tftypes.NewValue(tftypes.DynamicPseudoType, nil)
+	}
+
+	// The condition can't be checked until the attribute is known.
+	if !value.IsKnown() {
+		return
+	}
+
+	matches, known, err := v.predicate.Matches(value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Predicate",
+			err.Error(),
+		)
+		return
+	}
+	if !known || !matches {
+		return
+	}
+
+	reason := fmt.Sprintf("This applies because %s %s.", v.attribute, v.predicate.Description())
+	for _, validator := range v.validators {
+		validatorResp := &tfsdk.ValidateAttributeResponse{
+			Diagnostics: diag.Diagnostics{},
+		}
+		validator.Validate(ctx, req, validatorResp)
+
+		for _, d := range validatorResp.Diagnostics {
+			resp.Diagnostics.Append(withReason(d, reason))
+		}
+	}
+}
+
+// withReason returns the diagnostic with the reason appended to its detail.
+func withReason(d diag.Diagnostic, reason string) diag.Diagnostic {
+	detail := strings.TrimSpace(d.Detail() + " " + reason)
+
+	if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+		if d.Severity() == diag.SeverityWarning {
+			return diag.NewAttributeWarningDiagnostic(withPath.Path(), d.Summary(), detail)
+		}
+		return diag.NewAttributeErrorDiagnostic(withPath.Path(), d.Summary(), detail)
+	}
+	if d.Severity() == diag.SeverityWarning {
+		return diag.NewWarningDiagnostic(d.Summary(), detail)
+	}
+	return diag.NewErrorDiagnostic(d.Summary(), detail)
+}
+
+type isSetPredicate struct{}
+
+// IsSet is satisfied when the attribute is not null.
+func IsSet() Predicate {
+	return isSetPredicate{}
+}
+
+// Description describes this predicate.
+func (p isSetPredicate) Description() string {
+	return "is set"
+}
+
+// Matches reports whether the value satisfies this predicate.
+func (p isSetPredicate) Matches(value tftypes.Value) (bool, bool, error) {
+	return !value.IsNull(), true, nil
+}
+
+type equalsPredicate struct {
+	values []tftypes.Value
+	err    error
+}
+
+// Equals is satisfied when the attribute is equal to the value, which may
+// be a string, a bool or a finite number (any integer or float kind, or
+// *big.Float). Numbers are compared exactly. A value of any other type could
+// never be equal to an attribute, so the validator using the predicate
+// reports it as invalid.
+func Equals(value interface{}) Predicate {
+	return OneOf(value)
+}
+
+// OneOf is satisfied when the attribute is equal to any of the values,
+// which are the same as those accepted by Equals.
+func OneOf(values ...interface{}) Predicate {
+	p := equalsPredicate{}
+	for _, value := range values {
+		v, err := literal(value)
+		if err != nil {
+			p.err = err
+			continue
+		}
+		p.values = append(p.values, v)
+	}
+	return p
+}
+
+// Description describes this predicate.
+func (p equalsPredicate) Description() string {
+	if len(p.values) == 1 {
+		return "= " + formatValue(p.values[0])
+	}
+
+	formatted := make([]string, len(p.values))
+	for i, value := range p.values {
+		formatted[i] = formatValue(value)
+	}
+	return "is one of " + strings.Join(formatted, ", ")
+}
+
+// Matches reports whether the value satisfies this predicate.
+func (p equalsPredicate) Matches(value tftypes.Value) (bool, bool, error) {
+	if p.err != nil {
+		return false, false, p.err
+	}
+	if value.IsNull() {
+		return false, true, nil
+	}
+	// An unknown element may turn out to be equal to anything.
+	if !value.IsFullyKnown() {
+		return false, false, nil
+	}
+	for _, expected := range p.values {
+		if value.Type().Is(expected.Type()) && valueKey(value) == valueKey(expected) {
+			return true, true, nil
+		}
+	}
+	return false, true, nil
+}
+
+// literal returns the value as a tftypes.Value. An error is
+// returned if the value is not of a type supported by Equals.
+func literal(value interface{}) (tftypes.Value, error) {
+	switch v := value.(type) {
+	case string:
+		return tftypes.NewValue(tftypes.String, v), nil
+	case bool:
+		return tftypes.NewValue(tftypes.Bool, v), nil
+	case *big.Float:
+		if v == nil || v.IsInf() {
+			return tftypes.Value{}, fmt.Errorf("This validator was initialized with an invalid value: %v is not a finite number", v)
+		}
+		return tftypes.NewValue(tftypes.Number, v), nil
+	}
+
+	// Named types, e.g. time.Duration, are supported by their kind.
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(v.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return tftypes.NewValue(tftypes.Number, new(big.Float).SetUint64(v.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return tftypes.Value{}, fmt.Errorf("This validator was initialized with an invalid value: %v is not a finite number", f)
+		}
+		return tftypes.NewValue(tftypes.Number, big.NewFloat(v.Float())), nil
+	case reflect.String:
+		return tftypes.NewValue(tftypes.String, v.String()), nil
+	case reflect.Bool:
+		return tftypes.NewValue(tftypes.Bool, v.Bool()), nil
+	}
+	return tftypes.Value{}, fmt.Errorf("This validator was initialized with an unsupported value %#v of type %T, only strings, bools and numbers are supported", value, value)
+}
+
+type notPredicate struct {
+	predicate Predicate
+}
+
+// Not is satisfied when the predicate is not.
+func Not(predicate Predicate) Predicate {
+	return notPredicate{
+		predicate: predicate,
+	}
+}
+
+// Description describes this predicate.
+func (p notPredicate) Description() string {
+	switch inner := p.predicate.(type) {
+	case isSetPredicate:
+		return "is not set"
+	case equalsPredicate:
+		if len(inner.values) == 1 {
+			return "!= " + formatValue(inner.values[0])
+		}
+		return "is not " + strings.TrimPrefix(inner.Description(), "is ")
+	}
+	return "does not satisfy (" + p.predicate.Description() + ")"
+}
+
+// Matches reports whether the value satisfies this predicate.
+func (p notPredicate) Matches(value tftypes.Value) (bool, bool, error) {
+	matches, known, err := p.predicate.Matches(value)
+	return !matches, known, err
+}
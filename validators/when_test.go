@@ -0,0 +1,161 @@
+package validators
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhen(t *testing.T) {
+	path := tftypes.NewAttributePath().WithAttributeName("ip_address")
+
+	network := func(typ types.String, ipAddress types.String) map[string]attr.Value {
+		return map[string]attr.Value{
+			"type":       typ,
+			"ip_address": ipAddress,
+		}
+	}
+	static := types.String{Value: "static"}
+	dhcp := types.String{Value: "dhcp"}
+	address := types.String{Value: "10.0.0.1"}
+	null := types.String{Null: true}
+
+	for _, test := range []testCase{
+		{
+			name:      "required if pass",
+			validator: When("type", Equals("static"), Required()),
+			request:   testRequest("ip_address", network(static, address)),
+		},
+		{
+			name:      "required if fail",
+			validator: When("type", Equals("static"), Required()),
+			request:   testRequest("ip_address", network(static, null)),
+			err:       true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, requiredErr, `ip_address must be set. This applies because type = "static".`),
+			},
+		},
+		{
+			name:      "condition not met",
+			validator: When("type", Equals("static"), Required()),
+			request:   testRequest("ip_address", network(dhcp, null)),
+		},
+		{
+			name:      "forbidden if fail",
+			validator: When("type", Not(Equals("static")), Forbidden()),
+			request:   testRequest("ip_address", network(dhcp, address)),
+			err:       true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, forbiddenErr, `ip_address must not be set. This applies because type != "static".`),
+			},
+		},
+		{
+			name:      "one of",
+			validator: When("type", OneOf("static", "manual"), Required()),
+			request:   testRequest("ip_address", network(static, null)),
+			err:       true,
+		},
+		{
+			name:      "wrapped validators",
+			validator: When("type", IsSet(), Cidr()),
+			request:   testRequest("ip_address", network(dhcp, types.String{Value: "10.0.0.0/33"})),
+			err:       true,
+		},
+		{
+			name:      "unknown condition",
+			validator: When("type", Equals("static"), Required()),
+			request:   testRequest("ip_address", network(types.String{Unknown: true}, null)),
+		},
+		{
+			name:      "partially unknown condition",
+			validator: When("zones", Not(Equals("a")), Forbidden()),
+			request: testRequest("ip_address", map[string]attr.Value{
+				"zones": types.List{
+					ElemType: types.StringType,
+					Elems:    []attr.Value{types.String{Unknown: true}},
+				},
+				"ip_address": address,
+			}),
+		},
+		{
+			name:      "invalid predicate",
+			validator: When("type", Equals(math.NaN()), Required()),
+			request:   testRequest("ip_address", network(static, null)),
+			err:       true,
+			diagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path, "Invalid Predicate", "This validator was initialized with an invalid value: NaN is not a finite number"),
+			},
+		},
+		{
+			name:      "missing attribute",
+			validator: When("kind", Equals("static"), Required()),
+			request:   testRequest("ip_address", network(static, null)),
+			err:       true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	str := tftypes.NewValue(tftypes.String, "static")
+	number := tftypes.NewValue(tftypes.Number, big.NewFloat(3))
+	null := tftypes.NewValue(tftypes.String, nil)
+	partial := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+		tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	matches := func(p Predicate, value tftypes.Value) bool {
+		matches, known, err := p.Matches(value)
+		assert.NoError(t, err)
+		assert.True(t, known)
+		return matches
+	}
+
+	assert.True(t, matches(IsSet(), str))
+	assert.False(t, matches(IsSet(), null))
+	assert.True(t, matches(Not(IsSet()), null))
+	assert.True(t, matches(IsSet(), partial))
+
+	assert.True(t, matches(Equals("static"), str))
+	assert.False(t, matches(Equals("dhcp"), str))
+	assert.False(t, matches(Equals("static"), null))
+	assert.True(t, matches(Equals(3), number))
+	assert.True(t, matches(Equals(3.0), number))
+	assert.False(t, matches(Equals("3"), number))
+	assert.True(t, matches(Equals(uint8(3)), number))
+	assert.True(t, matches(Equals(int32(3)), number))
+	assert.True(t, matches(Equals(float32(3)), number))
+	assert.True(t, matches(Equals(time.Duration(3)), number))
+	assert.True(t, matches(OneOf(1, 2, 3), number))
+	assert.False(t, matches(OneOf(1, 2), number))
+
+	_, known, err := Not(Equals("static")).Matches(partial)
+	assert.NoError(t, err)
+	assert.False(t, known)
+
+	assert.Equal(t, `= "static"`, Equals("static").Description())
+	assert.Equal(t, `is one of 1, 2`, OneOf(1, 2).Description())
+	assert.Equal(t, `is not one of 1, 2`, Not(OneOf(1, 2)).Description())
+	assert.Equal(t, `is not set`, Not(IsSet()).Description())
+
+	for _, p := range []Predicate{
+		Equals([]string{"static"}),
+		OneOf("static", nil),
+		Equals(math.NaN()),
+		Equals(math.Inf(1)),
+		Not(Equals(float32(math.Inf(-1)))),
+	} {
+		_, _, err := p.Matches(str)
+		assert.Error(t, err)
+	}
+}